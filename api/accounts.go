@@ -0,0 +1,137 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/kwanifi/numiscan-api/dao/derrors"
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/log"
+)
+
+func (api *API) GetAccount(w http.ResponseWriter, r *http.Request) {
+	address, ok := mux.Vars(r)["address"]
+	if !ok || address == "" {
+		jsonBadRequest(w, "invalid address")
+		return
+	}
+	resp, err := api.svc.GetAccount(address)
+	if err != nil {
+		if err.Error() == derrors.ErrNotFound {
+			jsonNotFound(w)
+			return
+		}
+		log.Error("API GetAccount: svc.GetAccount: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
+func (api *API) GetAccountTransactions(w http.ResponseWriter, r *http.Request) {
+	address, ok := mux.Vars(r)["address"]
+	if !ok || address == "" {
+		jsonBadRequest(w, "invalid address")
+		return
+	}
+	var filter filters.AccountTransactions
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	if filter.Limit > 20 || filter.Limit == 0 {
+		filter.Limit = 20
+	}
+	filter.Address = address
+	resp, err := api.svc.GetAccountTransactions(filter)
+	if err != nil {
+		log.Error("API GetAccountTransactions: svc.GetAccountTransactions: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
+func (api *API) GetAccountBalanceAgg(w http.ResponseWriter, r *http.Request) {
+	address, ok := mux.Vars(r)["address"]
+	if !ok || address == "" {
+		jsonBadRequest(w, "invalid address")
+		return
+	}
+	var filter filters.BalanceUpdatesAgg
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	err = filter.Validate()
+	if err != nil {
+		log.Debug("API GetAccountBalanceAgg: Validate: %s", err.Error())
+		jsonBadRequest(w, err.Error())
+		return
+	}
+	filter.Address = address
+	resp, err := api.svc.GetAccountBalanceAgg(filter)
+	if err != nil {
+		log.Error("API GetAccountBalanceAgg: svc.GetAccountBalanceAgg: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
+func (api *API) GetTopAccounts(w http.ResponseWriter, r *http.Request) {
+	var filter filters.TopAccounts
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	if filter.Limit > 20 || filter.Limit == 0 {
+		filter.Limit = 20
+	}
+	resp, err := api.svc.GetTopAccounts(filter)
+	if err != nil {
+		log.Error("API GetTopAccounts: svc.GetTopAccounts: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
+func (api *API) GetAccountsDistribution(w http.ResponseWriter, r *http.Request) {
+	resp, err := api.svc.GetAccountsDistribution()
+	if err != nil {
+		log.Error("API GetAccountsDistribution: svc.GetAccountsDistribution: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
+func (api *API) GetAggAccountsDistribution(w http.ResponseWriter, r *http.Request) {
+	var filter filters.Agg
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	err = filter.Validate()
+	if err != nil {
+		log.Debug("API GetAggAccountsDistribution: Validate: %s", err.Error())
+		jsonBadRequest(w, err.Error())
+		return
+	}
+	resp, err := api.svc.GetAggAccountsDistribution(filter)
+	if err != nil {
+		log.Error("API GetAggAccountsDistribution: svc.GetAggAccountsDistribution: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/kwanifi/numiscan-api/log"
 	"github.com/kwanifi/numiscan-api/services"
 	"github.com/rs/cors"
+	"github.com/shopspring/decimal"
 	"github.com/urfave/negroni"
 	"go.uber.org/zap"
 )
@@ -45,6 +46,13 @@ func NewAPI(cfg config.Config, svc services.Services, dao dao.DAO) *API {
 		t := dmodels.NewTime(time.Unix(timestamp, 0))
 		return reflect.ValueOf(t)
 	})
+	sd.RegisterConverter(decimal.Decimal{}, func(s string) reflect.Value {
+		d, err := decimal.NewFromString(s)
+		if err != nil {
+			return reflect.Value{}
+		}
+		return reflect.ValueOf(d)
+	})
 	return &API{
 		cfg:          cfg,
 		dao:          dao,
@@ -98,8 +106,14 @@ func (api *API) loadRoutes() {
 		{Path: "/api", Method: http.MethodGet, Func: api.GetSwaggerAPI},
 
 		{Path: "/meta", Method: http.MethodGet, Func: api.GetMetaData},
+		{Path: "/blocks", Method: http.MethodGet, Func: api.GetBlocks},
+		{Path: "/block/{height}", Method: http.MethodGet, Func: api.GetBlock},
+		{Path: "/transactions", Method: http.MethodGet, Func: api.GetTransactions},
+		{Path: "/transaction/{hash}", Method: http.MethodGet, Func: api.GetTransaction},
 		{Path: "/historical-state", Method: http.MethodGet, Func: api.GetHistoricalState},
 		{Path: "/transactions/fee/agg", Method: http.MethodGet, Func: api.GetAggTransactionsFee},
+		{Path: "/transactions/types/agg", Method: http.MethodGet, Func: api.GetAggMessageTypes},
+		{Path: "/transactions/failed/agg", Method: http.MethodGet, Func: api.GetAggFailedTransactionsRate},
 		{Path: "/transfers/volume/agg", Method: http.MethodGet, Func: api.GetAggTransfersVolume},
 		{Path: "/operations/count/agg", Method: http.MethodGet, Func: api.GetAggOperationsCount},
 		{Path: "/blocks/count/agg", Method: http.MethodGet, Func: api.GetAggBlocksCount},
@@ -111,24 +125,47 @@ func (api *API) loadRoutes() {
 		{Path: "/unbonding/volume/agg", Method: http.MethodGet, Func: api.GetAggUnbondingVolume},
 		{Path: "/bonded-ratio/agg", Method: http.MethodGet, Func: api.GetAggBondedRatio},
 		{Path: "/network/stats", Method: http.MethodGet, Func: api.GetNetworkStats},
+		{Path: "/network/decentralization", Method: http.MethodGet, Func: api.GetDecentralization},
+		{Path: "/network/decentralization/agg", Method: http.MethodGet, Func: api.GetAggDecentralization},
 		{Path: "/staking/pie", Method: http.MethodGet, Func: api.GetStakingPie},
+		{Path: "/staking/apr", Method: http.MethodGet, Func: api.GetStakingAPR},
+		{Path: "/staking/calculator", Method: http.MethodGet, Func: api.GetStakingCalculation},
+		{Path: "/staking/unbonding/schedule", Method: http.MethodGet, Func: api.GetUnbondingSchedule},
+		{Path: "/staking/redelegations/flows", Method: http.MethodGet, Func: api.GetRedelegationFlows},
 		{Path: "/proposals", Method: http.MethodGet, Func: api.GetProposals},
 		{Path: "/proposals/votes", Method: http.MethodGet, Func: api.GetProposalVotes},
 		{Path: "/proposals/deposits", Method: http.MethodGet, Func: api.GetProposalDeposits},
 		{Path: "/proposals/chart", Method: http.MethodGet, Func: api.GetProposalChartData},
+		{Path: "/governance/validators", Method: http.MethodGet, Func: api.GetGovernanceValidators},
 		{Path: "/validators", Method: http.MethodGet, Func: api.GetValidators},
 		{Path: "/validators/33power/agg", Method: http.MethodGet, Func: api.GetAggValidators33Power},
 		{Path: "/validators/top/proposed", Method: http.MethodGet, Func: api.GetTopProposedBlocksValidators},
 		{Path: "/validators/top/jailed", Method: http.MethodGet, Func: api.GetMostJailedValidators},
+		{Path: "/validators/slashes", Method: http.MethodGet, Func: api.GetSlashes},
 		{Path: "/validators/fee/ranges", Method: http.MethodGet, Func: api.GetFeeRanges},
 		{Path: "/validators/delegators/total", Method: http.MethodGet, Func: api.GetValidatorsDelegatorsTotal},
+		{Path: "/validators/uptime", Method: http.MethodGet, Func: api.GetValidatorsUptime},
+		{Path: "/validators/powers", Method: http.MethodGet, Func: api.GetValidatorPowers},
 		{Path: "/accounts/whale/agg", Method: http.MethodGet, Func: api.GetAggWhaleAccounts},
+		{Path: "/accounts/top", Method: http.MethodGet, Func: api.GetTopAccounts},
+		{Path: "/accounts/distribution", Method: http.MethodGet, Func: api.GetAccountsDistribution},
+		{Path: "/accounts/distribution/agg", Method: http.MethodGet, Func: api.GetAggAccountsDistribution},
+		{Path: "/account/{address}", Method: http.MethodGet, Func: api.GetAccount},
+		{Path: "/account/{address}/transactions", Method: http.MethodGet, Func: api.GetAccountTransactions},
+		{Path: "/account/{address}/balance/agg", Method: http.MethodGet, Func: api.GetAccountBalanceAgg},
 		{Path: "/validator/{address}/balance", Method: http.MethodGet, Func: api.GetValidatorBalance},
 		{Path: "/validator/{address}/delegations/agg", Method: http.MethodGet, Func: api.GetValidatorDelegationsAgg},
 		{Path: "/validator/{address}/delegators/agg", Method: http.MethodGet, Func: api.GetValidatorDelegatorsAgg},
 		{Path: "/validator/{address}/blocks/stats", Method: http.MethodGet, Func: api.GetValidatorBlocksStat},
+		{Path: "/validator/{address}/uptime", Method: http.MethodGet, Func: api.GetValidatorUptime},
+		{Path: "/validator/{address}/revenue/agg", Method: http.MethodGet, Func: api.GetValidatorRevenueAgg},
+		{Path: "/validator/{address}/votes", Method: http.MethodGet, Func: api.GetValidatorVotes},
 		{Path: "/validator/{address}", Method: http.MethodGet, Func: api.GetValidator},
 		{Path: "/validator/{address}/delegators", Method: http.MethodGet, Func: api.GetValidatorDelegators},
+		{Path: "/validator/{address}/history", Method: http.MethodGet, Func: api.GetValidatorHistory},
+		{Path: "/validator/{address}/slashes", Method: http.MethodGet, Func: api.GetValidatorSlashes},
+		{Path: "/ibc/transfers", Method: http.MethodGet, Func: api.GetIBCTransfers},
+		{Path: "/ibc/channels/volume/agg", Method: http.MethodGet, Func: api.GetAggIBCChannelsVolume},
 	})
 
 }
@@ -172,6 +209,20 @@ func jsonBadRequest(writer http.ResponseWriter, msg string) {
 	writer.Write(bytes)
 }
 
+func jsonNotFound(writer http.ResponseWriter) {
+	bytes, err := json.Marshal(errResponse{
+		Error: "not_found",
+	})
+	if err != nil {
+		writer.WriteHeader(500)
+		writer.Write([]byte("can`t marshal json"))
+		return
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(404)
+	writer.Write(bytes)
+}
+
 func (api *API) GetSwaggerAPI(w http.ResponseWriter, r *http.Request) {
 	body, err := ioutil.ReadFile("./resources/templates/swagger.html")
 	if err != nil {
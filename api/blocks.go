@@ -2,6 +2,12 @@ package api
 
 import (
 	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kwanifi/numiscan-api/dao/derrors"
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/log"
 )
 
 func (api *API) GetAggBlocksCount(w http.ResponseWriter, r *http.Request) {
@@ -15,3 +21,42 @@ func (api *API) GetAggBlocksDelay(w http.ResponseWriter, r *http.Request) {
 func (api *API) GetAggUniqBlockValidators(w http.ResponseWriter, r *http.Request) {
 	api.aggHandler(w, r, api.svc.GetAggUniqBlockValidators)
 }
+
+func (api *API) GetBlocks(w http.ResponseWriter, r *http.Request) {
+	var filter filters.Blocks
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	if filter.Limit > 20 || filter.Limit == 0 {
+		filter.Limit = 20
+	}
+	resp, err := api.svc.GetBlocks(filter)
+	if err != nil {
+		log.Error("API GetBlocks: svc.GetBlocks: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
+func (api *API) GetBlock(w http.ResponseWriter, r *http.Request) {
+	height, err := strconv.ParseUint(mux.Vars(r)["height"], 10, 64)
+	if err != nil || height == 0 {
+		jsonBadRequest(w, "invalid height")
+		return
+	}
+	resp, err := api.svc.GetBlock(height)
+	if err != nil {
+		if err.Error() == derrors.ErrNotFound {
+			jsonNotFound(w)
+			return
+		}
+		log.Error("API GetBlock: svc.GetBlock: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/kwanifi/numiscan-api/dao/derrors"
 	"github.com/kwanifi/numiscan-api/dao/filters"
 	"github.com/kwanifi/numiscan-api/log"
 )
@@ -43,6 +44,83 @@ func (api *API) GetStakingPie(w http.ResponseWriter, r *http.Request) {
 	jsonData(w, resp)
 }
 
+func (api *API) GetStakingAPR(w http.ResponseWriter, r *http.Request) {
+	resp, err := api.svc.GetStakingAPR()
+	if err != nil {
+		log.Error("API GetStakingAPR: svc.GetStakingAPR: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
+func (api *API) GetUnbondingSchedule(w http.ResponseWriter, r *http.Request) {
+	var filter filters.UnbondingSchedule
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	resp, err := api.svc.GetUnbondingSchedule(filter)
+	if err != nil {
+		log.Error("API GetUnbondingSchedule: svc.GetUnbondingSchedule: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
+func (api *API) GetRedelegationFlows(w http.ResponseWriter, r *http.Request) {
+	var filter filters.RedelegationFlows
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	err = filter.Validate()
+	if err != nil {
+		log.Debug("API GetRedelegationFlows: Validate: %s", err.Error())
+		jsonBadRequest(w, err.Error())
+		return
+	}
+	resp, err := api.svc.GetRedelegationFlows(filter)
+	if err != nil {
+		log.Error("API GetRedelegationFlows: svc.GetRedelegationFlows: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
+func (api *API) GetStakingCalculation(w http.ResponseWriter, r *http.Request) {
+	var filter filters.StakingCalculator
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	err = filter.Validate()
+	if err != nil {
+		log.Debug("API GetStakingCalculation: Validate: %s", err.Error())
+		jsonBadRequest(w, err.Error())
+		return
+	}
+	resp, err := api.svc.GetStakingCalculation(filter)
+	if err != nil {
+		if err.Error() == derrors.ErrNotFound {
+			jsonNotFound(w)
+			return
+		}
+		log.Error("API GetStakingCalculation: svc.GetStakingCalculation: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
 func (api *API) GetValidatorDelegationsAgg(w http.ResponseWriter, r *http.Request) {
 	address, ok := mux.Vars(r)["address"]
 	if !ok || address == "" {
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/log"
+)
+
+func (api *API) GetIBCTransfers(w http.ResponseWriter, r *http.Request) {
+	var filter filters.IBCTransfers
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	if filter.Limit > 100 || filter.Limit == 0 {
+		filter.Limit = 100
+	}
+	resp, err := api.svc.GetIBCTransfers(filter)
+	if err != nil {
+		log.Error("API GetIBCTransfers: svc.GetIBCTransfers: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
+func (api *API) GetAggIBCChannelsVolume(w http.ResponseWriter, r *http.Request) {
+	var filter filters.IBCChannelsAgg
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	err = filter.Validate()
+	if err != nil {
+		log.Debug("API GetAggIBCChannelsVolume: Validate: %s", err.Error())
+		jsonBadRequest(w, err.Error())
+		return
+	}
+	resp, err := api.svc.GetAggIBCChannelsVolume(filter)
+	if err != nil {
+		log.Error("API GetAggIBCChannelsVolume: svc.GetAggIBCChannelsVolume: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
@@ -3,6 +3,8 @@ package api
 import (
 	"net/http"
 
+	"github.com/gorilla/mux"
+	"github.com/kwanifi/numiscan-api/dao/derrors"
 	"github.com/kwanifi/numiscan-api/dao/filters"
 	"github.com/kwanifi/numiscan-api/log"
 )
@@ -67,3 +69,58 @@ func (api *API) GetProposalChartData(w http.ResponseWriter, r *http.Request) {
 	}
 	jsonData(w, resp)
 }
+
+func (api *API) GetValidatorVotes(w http.ResponseWriter, r *http.Request) {
+	address, ok := mux.Vars(r)["address"]
+	if !ok || address == "" {
+		jsonBadRequest(w, "invalid address")
+		return
+	}
+	var filter filters.Participation
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	err = filter.Validate()
+	if err != nil {
+		log.Debug("API GetValidatorVotes: Validate: %s", err.Error())
+		jsonBadRequest(w, err.Error())
+		return
+	}
+	resp, err := api.svc.GetValidatorVotes(address, filter)
+	if err != nil {
+		if err.Error() == derrors.ErrNotFound {
+			jsonNotFound(w)
+			return
+		}
+		log.Error("API GetValidatorVotes: svc.GetValidatorVotes: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
+func (api *API) GetGovernanceValidators(w http.ResponseWriter, r *http.Request) {
+	var filter filters.Participation
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	err = filter.Validate()
+	if err != nil {
+		log.Debug("API GetGovernanceValidators: Validate: %s", err.Error())
+		jsonBadRequest(w, err.Error())
+		return
+	}
+	resp, err := api.svc.GetGovernanceValidators(filter)
+	if err != nil {
+		log.Error("API GetGovernanceValidators: svc.GetGovernanceValidators: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
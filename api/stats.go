@@ -35,3 +35,36 @@ func (api *API) GetAggWhaleAccounts(w http.ResponseWriter, r *http.Request) {
 func (api *API) GetAggBondedRatio(w http.ResponseWriter, r *http.Request) {
 	api.aggHandler(w, r, api.svc.GetAggBondedRatio)
 }
+
+func (api *API) GetDecentralization(w http.ResponseWriter, r *http.Request) {
+	resp, err := api.svc.GetDecentralization()
+	if err != nil {
+		log.Error("API GetDecentralization: svc.GetDecentralization: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
+func (api *API) GetAggDecentralization(w http.ResponseWriter, r *http.Request) {
+	var filter filters.Agg
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	err = filter.Validate()
+	if err != nil {
+		log.Debug("API GetAggDecentralization: Validate: %s", err.Error())
+		jsonBadRequest(w, err.Error())
+		return
+	}
+	resp, err := api.svc.GetAggDecentralization(filter)
+	if err != nil {
+		log.Error("API GetAggDecentralization: svc.GetAggDecentralization: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
@@ -2,6 +2,12 @@ package api
 
 import (
 	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/kwanifi/numiscan-api/dao/derrors"
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/log"
 )
 
 func (api *API) GetAggTransactionsFee(w http.ResponseWriter, r *http.Request) {
@@ -15,3 +21,79 @@ func (api *API) GetAggOperationsCount(w http.ResponseWriter, r *http.Request) {
 func (api *API) GetAvgOperationsPerBlock(w http.ResponseWriter, r *http.Request) {
 	api.aggHandler(w, r, api.svc.GetAvgOperationsPerBlock)
 }
+
+func (api *API) GetAggFailedTransactionsRate(w http.ResponseWriter, r *http.Request) {
+	api.aggHandler(w, r, api.svc.GetAggFailedTransactionsRate)
+}
+
+func (api *API) GetAggMessageTypes(w http.ResponseWriter, r *http.Request) {
+	var filter filters.MessageTypesAgg
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	err = filter.Validate()
+	if err != nil {
+		log.Debug("API GetAggMessageTypes: Validate: %s", err.Error())
+		jsonBadRequest(w, err.Error())
+		return
+	}
+	if filter.Limit > 20 || filter.Limit == 0 {
+		filter.Limit = 10
+	}
+	resp, err := api.svc.GetAggMessageTypes(filter)
+	if err != nil {
+		log.Error("API GetAggMessageTypes: svc.GetAggMessageTypes: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
+func (api *API) GetTransactions(w http.ResponseWriter, r *http.Request) {
+	var filter filters.Transactions
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	err = filter.Validate()
+	if err != nil {
+		log.Debug("API GetTransactions: Validate: %s", err.Error())
+		jsonBadRequest(w, err.Error())
+		return
+	}
+	if filter.Limit > 20 || filter.Limit == 0 {
+		filter.Limit = 20
+	}
+	resp, err := api.svc.GetTransactions(filter)
+	if err != nil {
+		log.Error("API GetTransactions: svc.GetTransactions: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
+func (api *API) GetTransaction(w http.ResponseWriter, r *http.Request) {
+	hash, ok := mux.Vars(r)["hash"]
+	if !ok || hash == "" {
+		jsonBadRequest(w, "invalid hash")
+		return
+	}
+	// hashes are kept in the upper case hex returned by the node
+	resp, err := api.svc.GetTransaction(strings.ToUpper(hash))
+	if err != nil {
+		if err.Error() == derrors.ErrNotFound {
+			jsonNotFound(w)
+			return
+		}
+		log.Error("API GetTransaction: svc.GetTransaction: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/kwanifi/numiscan-api/dao/filters"
 	"github.com/kwanifi/numiscan-api/log"
 )
 
@@ -105,3 +106,161 @@ func (api *API) GetValidatorBlocksStat(w http.ResponseWriter, r *http.Request) {
 	}
 	jsonData(w, resp)
 }
+
+func (api *API) GetValidatorUptime(w http.ResponseWriter, r *http.Request) {
+	address, ok := mux.Vars(r)["address"]
+	if !ok || address == "" {
+		jsonBadRequest(w, "invalid address")
+		return
+	}
+	var filter filters.Uptime
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	err = filter.Validate()
+	if err != nil {
+		log.Debug("API GetValidatorUptime: Validate: %s", err.Error())
+		jsonBadRequest(w, err.Error())
+		return
+	}
+	resp, err := api.svc.GetValidatorUptime(address, filter)
+	if err != nil {
+		log.Error("API GetValidatorUptime: svc.GetValidatorUptime: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
+func (api *API) GetValidatorsUptime(w http.ResponseWriter, r *http.Request) {
+	resp, err := api.svc.GetValidatorsUptime()
+	if err != nil {
+		log.Error("API GetValidatorsUptime: svc.GetValidatorsUptime: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
+func (api *API) GetValidatorHistory(w http.ResponseWriter, r *http.Request) {
+	address, ok := mux.Vars(r)["address"]
+	if !ok || address == "" {
+		jsonBadRequest(w, "invalid address")
+		return
+	}
+	var filter filters.ValidatorEvents
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	if filter.Limit > 20 || filter.Limit == 0 {
+		filter.Limit = 20
+	}
+	filter.Validator = address
+	resp, err := api.svc.GetValidatorHistory(filter)
+	if err != nil {
+		log.Error("API GetValidatorHistory: svc.GetValidatorHistory: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
+func (api *API) GetSlashes(w http.ResponseWriter, r *http.Request) {
+	var filter filters.Slashes
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	if filter.Limit > 20 || filter.Limit == 0 {
+		filter.Limit = 20
+	}
+	resp, err := api.svc.GetSlashes(filter)
+	if err != nil {
+		log.Error("API GetSlashes: svc.GetSlashes: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
+func (api *API) GetValidatorSlashes(w http.ResponseWriter, r *http.Request) {
+	address, ok := mux.Vars(r)["address"]
+	if !ok || address == "" {
+		jsonBadRequest(w, "invalid address")
+		return
+	}
+	var filter filters.Slashes
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	if filter.Limit > 20 || filter.Limit == 0 {
+		filter.Limit = 20
+	}
+	resp, err := api.svc.GetValidatorSlashes(address, filter)
+	if err != nil {
+		log.Error("API GetValidatorSlashes: svc.GetValidatorSlashes: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
+func (api *API) GetValidatorPowers(w http.ResponseWriter, r *http.Request) {
+	var filter filters.ValidatorPowers
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	resp, err := api.svc.GetValidatorPowers(filter)
+	if err != nil {
+		log.Error("API GetValidatorPowers: svc.GetValidatorPowers: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
+
+func (api *API) GetValidatorRevenueAgg(w http.ResponseWriter, r *http.Request) {
+	address, ok := mux.Vars(r)["address"]
+	if !ok || address == "" {
+		jsonBadRequest(w, "invalid address")
+		return
+	}
+	var filter filters.ValidatorRevenueAgg
+	err := api.queryDecoder.Decode(&filter, r.URL.Query())
+	if err != nil {
+		log.Debug("API Decode: %s", err.Error())
+		jsonBadRequest(w, "")
+		return
+	}
+	if filter.By == "" {
+		filter.By = filters.AggByDay
+	}
+	err = filter.Validate()
+	if err != nil {
+		log.Debug("API GetValidatorRevenueAgg: Validate: %s", err.Error())
+		jsonBadRequest(w, err.Error())
+		return
+	}
+	filter.Validator = address
+	resp, err := api.svc.GetValidatorRevenueAgg(filter)
+	if err != nil {
+		log.Error("API GetValidatorRevenueAgg: svc.GetValidatorRevenueAgg: %s", err.Error())
+		jsonError(w)
+		return
+	}
+	jsonData(w, resp)
+}
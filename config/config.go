@@ -5,26 +5,95 @@ import (
 	"io/ioutil"
 	"log"
 	"path/filepath"
+
+	"github.com/shopspring/decimal"
 )
 
 const (
 	ServiceName = "numiscan-api"
 	configPath  = "./config.json"
-	Currency    = "atom"
+
+	// GenesisFromNode makes the parser load the genesis from the `/genesis` RPC of the node.
+	GenesisFromNode = "node"
+
+	ParserSourceLCD  = "lcd"
+	ParserSourceRPC  = "rpc"
+	ParserSourceGRPC = "grpc"
+
+	NodeClientREST = "rest"
+	NodeClientGRPC = "grpc"
+
+	defaultAccountsFetchers   = 5
+	defaultDistributionRanges = 8
+	// defaultPowerReduction is sdk.DefaultPowerReduction of the cosmos-sdk.
+	defaultPowerReduction = 1000000
 )
 
+// DefaultChain describes the Cosmos Hub and fills missing fields of the `chain` section.
+var DefaultChain = Chain{
+	Title:           "hub3",
+	BaseDenom:       "uatom",
+	DisplayDenom:    "atom",
+	Exponent:        6,
+	AccountPrefix:   "cosmos",
+	ValidatorPrefix: "cosmosvaloper",
+	ConsensusPrefix: "cosmosvalcons",
+	Genesis:         "https://raw.githubusercontent.com/cosmos/launch/master/genesis.json",
+	PowerReduction:  defaultPowerReduction,
+}
+
 type (
 	Config struct {
 		API        API        `json:"api"`
 		Mysql      Mysql      `json:"mysql"`
 		Clickhouse Clickhouse `json:"clickhouse"`
 		Parser     Parser     `json:"parser"`
+		Accounts   Accounts   `json:"accounts"`
+		Chain      Chain      `json:"chain"`
 		CMCKey     string     `json:"cmc_key"`
 	}
+	Chain struct {
+		Title           string `json:"title"`
+		BaseDenom       string `json:"base_denom"`
+		DisplayDenom    string `json:"display_denom"`
+		Exponent        int32  `json:"exponent"`
+		AccountPrefix   string `json:"account_prefix"`
+		ValidatorPrefix string `json:"validator_prefix"`
+		ConsensusPrefix string `json:"consensus_prefix"`
+		Genesis         string `json:"genesis"`
+		// PowerReduction is the number of base denom units in one unit of tendermint voting power.
+		PowerReduction int64 `json:"power_reduction"`
+	}
 	Parser struct {
-		Node     string `json:"node"`
-		Batch    uint64 `json:"batch"`
-		Fetchers uint64 `json:"fetchers"`
+		Node       string  `json:"node"`
+		RPC        string  `json:"rpc"`
+		GRPC       string  `json:"grpc"`
+		Source     string  `json:"source"`
+		NodeClient string  `json:"node_client"`
+		Batch      uint64  `json:"batch"`
+		Fetchers   uint64  `json:"fetchers"`
+		Denoms     []Denom `json:"denoms"`
+		Rollback   bool    `json:"rollback"`
+		Stream     bool    `json:"stream"`
+	}
+	// Accounts configures the refresh of the account balances from the node,
+	// the rate limit is the number of accounts refreshed per second, zero means no limit.
+	Accounts struct {
+		Fetchers     uint64       `json:"fetchers"`
+		RateLimit    uint64       `json:"rate_limit"`
+		Distribution Distribution `json:"distribution"`
+	}
+	// Distribution describes the log-scale ranges of the holders distribution:
+	// [0, min), [min, min*base), [min*base, min*base^2) and so on, the last range has no upper bound.
+	Distribution struct {
+		Min    decimal.Decimal `json:"min"`
+		Base   decimal.Decimal `json:"base"`
+		Ranges uint64          `json:"ranges"`
+	}
+	Denom struct {
+		Denom    string `json:"denom"`
+		Display  string `json:"display"`
+		Exponent int32  `json:"exponent"`
 	}
 	API struct {
 		Port         string   `json:"port"`
@@ -58,5 +127,93 @@ func GetConfig() Config {
 	if err != nil {
 		log.Fatalln("Failed unmarshal config ", err)
 	}
+	config.Chain.setDefaults()
+	if config.Parser.Source == "" {
+		config.Parser.Source = ParserSourceLCD
+	}
+	switch config.Parser.Source {
+	case ParserSourceLCD, ParserSourceRPC, ParserSourceGRPC:
+	default:
+		log.Fatalln("Unknown parser source: " + config.Parser.Source)
+	}
+	// without the tendermint RPC for the other sources the slashes and validator revenues are not parsed
+	if config.Parser.RPC == "" && (config.Parser.Source == ParserSourceRPC || config.Parser.Stream) {
+		log.Fatalln("parser.rpc is required for the rpc source and the stream")
+	}
+	if config.Accounts.Fetchers == 0 {
+		config.Accounts.Fetchers = defaultAccountsFetchers
+	}
+	config.Accounts.Distribution.setDefaults()
+	if config.Parser.NodeClient == "" {
+		config.Parser.NodeClient = NodeClientREST
+	}
+	if config.Parser.NodeClient != NodeClientREST && config.Parser.NodeClient != NodeClientGRPC {
+		log.Fatalln("Unknown node client: " + config.Parser.NodeClient)
+	}
 	return config
 }
+
+func (d *Distribution) setDefaults() {
+	if d.Min.IsZero() {
+		d.Min = decimal.New(1, 0)
+	}
+	if d.Base.LessThanOrEqual(decimal.New(1, 0)) {
+		d.Base = decimal.New(10, 0)
+	}
+	if d.Ranges < 2 {
+		d.Ranges = defaultDistributionRanges
+	}
+}
+
+// Bounds returns the lower bounds of the distribution ranges.
+func (d Distribution) Bounds() []decimal.Decimal {
+	bounds := []decimal.Decimal{decimal.Zero}
+	bound := d.Min
+	for i := uint64(1); i < d.Ranges; i++ {
+		bounds = append(bounds, bound)
+		bound = bound.Mul(d.Base)
+	}
+	return bounds
+}
+
+func (c *Chain) setDefaults() {
+	if c.Title == "" {
+		c.Title = DefaultChain.Title
+	}
+	if c.BaseDenom == "" {
+		c.BaseDenom = DefaultChain.BaseDenom
+		c.DisplayDenom = DefaultChain.DisplayDenom
+		c.Exponent = DefaultChain.Exponent
+	}
+	if c.DisplayDenom == "" {
+		c.DisplayDenom = c.BaseDenom
+	}
+	if c.AccountPrefix == "" {
+		c.AccountPrefix = DefaultChain.AccountPrefix
+	}
+	if c.ValidatorPrefix == "" {
+		c.ValidatorPrefix = c.AccountPrefix + "valoper"
+	}
+	if c.ConsensusPrefix == "" {
+		c.ConsensusPrefix = c.AccountPrefix + "valcons"
+	}
+	if c.PowerReduction <= 0 {
+		c.PowerReduction = DefaultChain.PowerReduction
+	}
+	if c.Genesis == "" {
+		c.Genesis = GenesisFromNode
+		if c.Title == DefaultChain.Title {
+			c.Genesis = DefaultChain.Genesis
+		}
+	}
+}
+
+// PrecisionDiv returns the divider from the base denom to the display one.
+func (c Chain) PrecisionDiv() decimal.Decimal {
+	return decimal.New(1, c.Exponent)
+}
+
+// Power returns the amount of base denom units in the tendermint voting power.
+func (c Chain) Power(power int64) decimal.Decimal {
+	return decimal.NewFromInt(power).Mul(decimal.NewFromInt(c.PowerReduction))
+}
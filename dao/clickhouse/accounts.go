@@ -6,6 +6,7 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/kwanifi/numiscan-api/dao/filters"
 	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/kwanifi/numiscan-api/smodels"
 )
 
 func (db DB) GetActiveAccounts(filter filters.ActiveAccounts) (addresses []string, err error) {
@@ -45,3 +46,52 @@ func (db DB) GetActiveAccounts(filter filters.ActiveAccounts) (addresses []strin
 	err = db.Find(&addresses, query)
 	return addresses, err
 }
+
+// GetAccountTransactions returns the transfers, delegations, rewards, votes and deposits of the address, newest first.
+func (db DB) GetAccountTransactions(filter filters.AccountTransactions) (items []smodels.AccountTransaction, err error) {
+	q := squirrel.Select("*").FromSelect(accountTransactionsQuery(filter.Address), "t").OrderBy("created_at desc", "tx_hash", "type")
+	if filter.Limit != 0 {
+		q = q.Limit(filter.Limit)
+	}
+	if filter.Offset != 0 {
+		q = q.Offset(filter.Offset)
+	}
+	err = db.Find(&items, q)
+	return items, err
+}
+
+func (db DB) GetAccountTransactionsTotal(filter filters.AccountTransactions) (total uint64, err error) {
+	q := squirrel.Select("count(*) as total").FromSelect(accountTransactionsQuery(filter.Address), "t")
+	err = db.FindFirst(&total, q)
+	return total, err
+}
+
+func accountTransactionsQuery(address string) squirrel.SelectBuilder {
+	qs := []squirrel.SelectBuilder{
+		squirrel.Select("toString(trf_tx_hash) as tx_hash", fmt.Sprintf("'%s' as type", smodels.AccountTransactionTransferOut),
+			"trf_amount as amount", "trf_currency as currency", "trf_to as counterparty", "trf_created_at as created_at").
+			From(dmodels.TransfersTable).Where(squirrel.Eq{"trf_from": address}),
+		squirrel.Select("toString(trf_tx_hash) as tx_hash", fmt.Sprintf("'%s' as type", smodels.AccountTransactionTransferIn),
+			"trf_amount as amount", "trf_currency as currency", "trf_from as counterparty", "trf_created_at as created_at").
+			From(dmodels.TransfersTable).Where(squirrel.Eq{"trf_to": address}),
+		squirrel.Select("toString(dlg_tx_hash) as tx_hash",
+			fmt.Sprintf("if(dlg_amount < 0, '%s', '%s') as type", smodels.AccountTransactionUndelegation, smodels.AccountTransactionDelegation),
+			"abs(dlg_amount) as amount", "'' as currency", "dlg_validator as counterparty", "dlg_created_at as created_at").
+			From(dmodels.DelegationsTable).Where(squirrel.Eq{"dlg_delegator": address}),
+		squirrel.Select("toString(der_tx_hash) as tx_hash", fmt.Sprintf("'%s' as type", smodels.AccountTransactionReward),
+			"der_amount as amount", "der_currency as currency", "der_validator as counterparty", "der_created_at as created_at").
+			From(dmodels.DelegatorRewardsTable).Where(squirrel.Eq{"der_delegator": address}),
+		squirrel.Select("toString(prv_tx_hash) as tx_hash", fmt.Sprintf("'%s' as type", smodels.AccountTransactionVote),
+			"toDecimal128(0, 18) as amount", "'' as currency", "toString(prv_proposal_id) as counterparty", "prv_created_at as created_at").
+			From(dmodels.ProposalVotesTable).Where(squirrel.Eq{"prv_voter": address}),
+		squirrel.Select("prd_tx_hash as tx_hash", fmt.Sprintf("'%s' as type", smodels.AccountTransactionDeposit),
+			"prd_amount as amount", "prd_currency as currency", "toString(prd_proposal_id) as counterparty", "prd_created_at as created_at").
+			From(dmodels.ProposalDepositsTable).Where(squirrel.Eq{"prd_depositor": address}),
+	}
+	q := qs[0]
+	for i := 1; i < len(qs); i++ {
+		sql, args, _ := qs[i].ToSql()
+		q = q.Suffix("UNION ALL "+sql, args...)
+	}
+	return q
+}
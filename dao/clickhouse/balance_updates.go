@@ -6,6 +6,7 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/kwanifi/numiscan-api/dao/filters"
 	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/kwanifi/numiscan-api/smodels"
 )
 
 func (db DB) CreateBalanceUpdates(updates []dmodels.BalanceUpdate) error {
@@ -27,6 +28,10 @@ func (db DB) CreateBalanceUpdates(updates []dmodels.BalanceUpdate) error {
 
 func (db DB) GetBalanceUpdate(filter filters.BalanceUpdates) (updates []dmodels.BalanceUpdate, err error) {
 	q := squirrel.Select("*").From(dmodels.BalanceUpdatesTable).OrderBy("bau_created_at desc")
+	if filter.Address != "" {
+		q = q.Where(squirrel.Eq{"bau_address": filter.Address})
+	}
+	q = filter.Query("bau_created_at", q)
 	if filter.Limit != 0 {
 		q = q.Limit(filter.Limit)
 	}
@@ -36,3 +41,24 @@ func (db DB) GetBalanceUpdate(filter filters.BalanceUpdates) (updates []dmodels.
 	err = db.Find(&updates, q)
 	return updates, err
 }
+
+// GetAggBalanceUpdates returns the last known balance of the address in every period with changes.
+func (db DB) GetAggBalanceUpdates(filter filters.BalanceUpdatesAgg) (items []smodels.BalanceAggItem, err error) {
+	q := squirrel.Select(
+		fmt.Sprintf("toDateTime(%s(bau_created_at)) AS time", filter.AggFunc()),
+		"argMax(bau_balance, bau_created_at) AS balance",
+		"argMax(bau_stake, bau_created_at) AS stake",
+		"argMax(bau_unbonding, bau_created_at) AS unbonding",
+	).From(dmodels.BalanceUpdatesTable).
+		Where(squirrel.Eq{"bau_address": filter.Address}).
+		GroupBy("time").
+		OrderBy("time")
+	if !filter.From.IsZero() {
+		q = q.Where(squirrel.GtOrEq{"bau_created_at": filter.From.Time})
+	}
+	if !filter.To.IsZero() {
+		q = q.Where(squirrel.LtOrEq{"bau_created_at": filter.To.Time})
+	}
+	err = db.Find(&items, q)
+	return items, err
+}
@@ -33,7 +33,10 @@ func (db DB) CreateBlocks(blocks []dmodels.Block) error {
 }
 
 func (db DB) GetBlocks(filter filters.Blocks) (blocks []dmodels.Block, err error) {
-	q := squirrel.Select("*").From(dmodels.BlocksTable).OrderBy("blk_id desc")
+	q := squirrel.Select("*").From(fmt.Sprintf("%s FINAL", dmodels.BlocksTable)).OrderBy("blk_id desc")
+	if len(filter.Heights) != 0 {
+		q = q.Where(squirrel.Eq{"blk_id": filter.Heights})
+	}
 	if filter.Limit != 0 {
 		q = q.Limit(filter.Limit)
 	}
@@ -44,6 +47,15 @@ func (db DB) GetBlocks(filter filters.Blocks) (blocks []dmodels.Block, err error
 	return blocks, err
 }
 
+func (db DB) GetBlocksTotal(filter filters.Blocks) (total uint64, err error) {
+	q := squirrel.Select("count(*) as total").From(fmt.Sprintf("%s FINAL", dmodels.BlocksTable))
+	if len(filter.Heights) != 0 {
+		q = q.Where(squirrel.Eq{"blk_id": filter.Heights})
+	}
+	err = db.FindFirst(&total, q)
+	return total, err
+}
+
 func (db DB) GetAggBlocksCount(filter filters.Agg) (items []smodels.AggItem, err error) {
 	q := filter.BuildQuery("toDecimal64(count(blk_id), 0)", "blk_created_at", dmodels.BlocksTable)
 	err = db.Find(&items, q)
@@ -93,7 +105,7 @@ func (db DB) GetAvgBlocksDelay(filter filters.TimeRange) (delay float64, err err
 }
 
 func (db DB) GetProposedBlocksTotal(filter filters.BlocksProposed) (total uint64, err error) {
-	q := squirrel.Select("count(*) as total").From(dmodels.BlocksTable)
+	q := squirrel.Select("count(*) as total").From(fmt.Sprintf("%s FINAL", dmodels.BlocksTable))
 	if len(filter.Proposers) != 0 {
 		q = q.Where(squirrel.Eq{"blk_proposer": filter.Proposers})
 	}
@@ -20,7 +20,17 @@ type DB struct {
 	conn *sqlx.DB
 }
 
-func NewDB(cfg config.Clickhouse) (*DB, error) {
+// currencyColumns were added without the currency, the rows stored before are of the staking denom.
+var currencyColumns = []struct {
+	table  string
+	column string
+}{
+	{table: "delegator_rewards", column: "der_currency"},
+	{table: "validator_rewards", column: "var_currency"},
+	{table: "proposal_deposits", column: "prd_currency"},
+}
+
+func NewDB(cfg config.Clickhouse, chain config.Chain) (*DB, error) {
 	conn, err := sql.Open("clickhouse", makeSource(cfg))
 	if err != nil {
 		return nil, fmt.Errorf("can`t make connection: %s", err.Error())
@@ -29,9 +39,33 @@ func NewDB(cfg config.Clickhouse) (*DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("can`t make makeMigration: %s", err.Error())
 	}
-	return &DB{
+	db := &DB{
 		conn: sqlx.NewDb(conn, "clickhouse"),
-	}, nil
+	}
+	err = db.fillCurrencies(chain.DisplayDenom)
+	if err != nil {
+		return nil, fmt.Errorf("fillCurrencies: %s", err.Error())
+	}
+	return db, nil
+}
+
+// fillCurrencies sets the staking denom to the rows stored before the currency columns were added.
+func (db *DB) fillCurrencies(currency string) error {
+	for _, c := range currencyColumns {
+		var total uint64
+		err := db.conn.Get(&total, fmt.Sprintf("SELECT count() FROM %s WHERE %s = ''", c.table, c.column))
+		if err != nil {
+			return fmt.Errorf("%s: %s", c.table, err.Error())
+		}
+		if total == 0 {
+			continue
+		}
+		_, err = db.conn.Exec(fmt.Sprintf("ALTER TABLE %s UPDATE %s = ? WHERE %s = ''", c.table, c.column, c.column), currency)
+		if err != nil {
+			return fmt.Errorf("%s: %s", c.table, err.Error())
+		}
+	}
+	return nil
 }
 
 func (db *DB) Find(dest interface{}, b squirrel.SelectBuilder) error {
@@ -85,7 +119,7 @@ func makeSource(cfg config.Clickhouse) string {
 }
 
 func makeMigration(conn *sql.DB, migrationDir string, dbName string) error {
-	driver, err := goclickhouse.WithInstance(conn, &goclickhouse.Config{})
+	driver, err := goclickhouse.WithInstance(conn, &goclickhouse.Config{MultiStatementEnabled: true})
 	if err != nil {
 		return fmt.Errorf("clickhouse.WithInstance: %s", err.Error())
 	}
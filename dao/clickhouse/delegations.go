@@ -36,6 +36,27 @@ func (db DB) CreateDelegations(delegations []dmodels.Delegation) error {
 	return db.Insert(q)
 }
 
+func (db DB) GetDelegations(filter filters.Delegations) (delegations []dmodels.Delegation, err error) {
+	q := squirrel.Select("*").From(fmt.Sprintf("%s FINAL", dmodels.DelegationsTable)).OrderBy("dlg_id")
+	if filter.TxHash != "" {
+		q = q.Where(squirrel.Eq{"dlg_tx_hash": filter.TxHash})
+	}
+	err = db.Find(&delegations, q)
+	return delegations, err
+}
+
+// GetAccountDelegations returns the current stake of the delegator per validator.
+func (db DB) GetAccountDelegations(address string) (items []smodels.AccountDelegation, err error) {
+	q := squirrel.Select("dlg_validator as validator", "sum(dlg_amount) as amount").
+		From(dmodels.DelegationsTable).
+		Where(squirrel.Eq{"dlg_delegator": address}).
+		GroupBy("dlg_validator").
+		Having(squirrel.Gt{"amount": 0}).
+		OrderBy("amount desc")
+	err = db.Find(&items, q)
+	return items, err
+}
+
 func (db DB) GetAggDelegationsVolume(filter filters.DelegationsAgg) (items []smodels.AggItem, err error) {
 	q := filter.BuildQuery("sum(dlg_amount)", "dlg_created_at", dmodels.DelegationsTable)
 	if len(filter.Validators) != 0 {
@@ -85,19 +106,6 @@ func (db DB) GetUndelegationsVolume(filter filters.TimeRange) (total decimal.Dec
 	return total, err
 }
 
-func (db DB) GetVotingPower(filter filters.VotingPower) (volume decimal.Decimal, err error) {
-	q := squirrel.Select("sum(dlg_amount) as volume").From(dmodels.DelegationsTable)
-	q = filter.Query("dlg_created_at", q)
-	if len(filter.Delegators) != 0 {
-		q = q.Where(squirrel.Eq{"dlg_delegator": filter.Delegators})
-	}
-	if len(filter.Validators) != 0 {
-		q = q.Where(squirrel.Eq{"dlg_validator": filter.Validators})
-	}
-	err = db.FindFirst(&volume, q)
-	return volume, err
-}
-
 func (db DB) GetValidatorsDelegatorsTotal() (values []dmodels.ValidatorValue, err error) {
 	q1 := squirrel.Select("sum(dlg_amount) as volume", "dlg_delegator", "dlg_validator").
 		From(dmodels.DelegationsTable).
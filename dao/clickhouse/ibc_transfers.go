@@ -0,0 +1,114 @@
+package clickhouse
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/kwanifi/numiscan-api/smodels"
+)
+
+const ibcLocalChannel = "if(ibt_direction = 'in', ibt_destination_channel, ibt_source_channel)"
+
+func (db DB) CreateIBCTransfers(transfers []dmodels.IBCTransfer) error {
+	if len(transfers) == 0 {
+		return nil
+	}
+	q := squirrel.Insert(dmodels.IBCTransfersTable).Columns("ibt_id", "ibt_tx_hash", "ibt_ack_tx_hash", "ibt_direction",
+		"ibt_status", "ibt_source_port", "ibt_source_channel", "ibt_destination_port", "ibt_destination_channel",
+		"ibt_sequence", "ibt_sender", "ibt_receiver", "ibt_denom", "ibt_amount", "ibt_created_at", "ibt_updated_at")
+	for _, transfer := range transfers {
+		if transfer.ID == "" {
+			return fmt.Errorf("field ID can not be empty")
+		}
+		if transfer.TxHash == "" {
+			return fmt.Errorf("field TxHash can not be empty")
+		}
+		if transfer.Status == "" {
+			return fmt.Errorf("field Status can not be empty")
+		}
+		if transfer.Sequence == 0 {
+			return fmt.Errorf("field Sequence can not be zero")
+		}
+		if transfer.CreatedAt.IsZero() {
+			return fmt.Errorf("field CreatedAt can not be zero")
+		}
+		if transfer.UpdatedAt.IsZero() {
+			return fmt.Errorf("field UpdatedAt can not be zero")
+		}
+		q = q.Values(transfer.ID, transfer.TxHash, transfer.AckTxHash, transfer.Direction, transfer.Status,
+			transfer.SourcePort, transfer.SourceChannel, transfer.DestinationPort, transfer.DestinationChannel,
+			transfer.Sequence, transfer.Sender, transfer.Receiver, transfer.Denom, transfer.Amount,
+			transfer.CreatedAt, transfer.UpdatedAt)
+	}
+	return db.Insert(q)
+}
+
+func (db DB) GetIBCTransfers(filter filters.IBCTransfers) (transfers []dmodels.IBCTransfer, err error) {
+	q := squirrel.Select("*").From(fmt.Sprintf("%s FINAL", dmodels.IBCTransfersTable)).OrderBy("ibt_created_at desc")
+	q = ibcTransfersQuery(filter, q)
+	if filter.Limit != 0 {
+		q = q.Limit(filter.Limit)
+	}
+	if filter.Offset != 0 {
+		q = q.Offset(filter.Offset)
+	}
+	err = db.Find(&transfers, q)
+	return transfers, err
+}
+
+func (db DB) GetIBCTransfersTotal(filter filters.IBCTransfers) (total uint64, err error) {
+	q := squirrel.Select("count(*) as total").From(fmt.Sprintf("%s FINAL", dmodels.IBCTransfersTable))
+	q = ibcTransfersQuery(filter, q)
+	err = db.FindFirst(&total, q)
+	return total, err
+}
+
+func (db DB) GetAggIBCChannelsVolume(filter filters.IBCChannelsAgg) (items []smodels.IBCChannelAggItem, err error) {
+	q := squirrel.Select(
+		"sum(ibt_amount) AS value",
+		fmt.Sprintf("toDateTime(%s(ibt_created_at)) AS time", filter.AggFunc()),
+		fmt.Sprintf("%s AS channel", ibcLocalChannel),
+		"ibt_denom AS denom",
+	).From(fmt.Sprintf("%s FINAL", dmodels.IBCTransfersTable)).
+		Where(squirrel.NotEq{"ibt_status": []string{dmodels.IBCTransferStatusFailed, dmodels.IBCTransferStatusTimeout}}).
+		GroupBy("time", "channel", "denom").
+		OrderBy("time", "channel", "denom")
+	if filter.Denom != "" {
+		q = q.Where(squirrel.Eq{"ibt_denom": filter.Denom})
+	}
+	if len(filter.Channels) != 0 {
+		q = q.Where(squirrel.Eq{ibcLocalChannel: filter.Channels})
+	}
+	if !filter.From.IsZero() {
+		q = q.Where(squirrel.GtOrEq{"ibt_created_at": filter.From.Time})
+	}
+	if !filter.To.IsZero() {
+		q = q.Where(squirrel.LtOrEq{"ibt_created_at": filter.To.Time})
+	}
+	err = db.Find(&items, q)
+	return items, err
+}
+
+func ibcTransfersQuery(filter filters.IBCTransfers, q squirrel.SelectBuilder) squirrel.SelectBuilder {
+	if len(filter.ID) != 0 {
+		q = q.Where(squirrel.Eq{"ibt_id": filter.ID})
+	}
+	if filter.Channel != "" {
+		q = q.Where(squirrel.Eq{ibcLocalChannel: filter.Channel})
+	}
+	if filter.Address != "" {
+		q = q.Where(squirrel.Or{
+			squirrel.Eq{"ibt_sender": filter.Address},
+			squirrel.Eq{"ibt_receiver": filter.Address},
+		})
+	}
+	if filter.Status != "" {
+		q = q.Where(squirrel.Eq{"ibt_status": filter.Status})
+	}
+	if filter.Direction != "" {
+		q = q.Where(squirrel.Eq{"ibt_direction": filter.Direction})
+	}
+	return q
+}
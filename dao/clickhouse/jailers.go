@@ -11,7 +11,7 @@ func (db DB) CreateJailers(jailers []dmodels.Jailer) error {
 	if len(jailers) == 0 {
 		return nil
 	}
-	q := squirrel.Insert(dmodels.JailersTable).Columns("jlr_id", "jlr_address", "jlr_created_at")
+	q := squirrel.Insert(dmodels.JailersTable).Columns("jlr_id", "jlr_address", "jlr_tx_hash", "jlr_created_at")
 	for _, jailer := range jailers {
 		if jailer.ID == "" {
 			return fmt.Errorf("field ProposalID can not be empty")
@@ -22,7 +22,7 @@ func (db DB) CreateJailers(jailers []dmodels.Jailer) error {
 		if jailer.CreatedAt.IsZero() {
 			return fmt.Errorf("field CreatedAt can not be zero")
 		}
-		q = q.Values(jailer.ID, jailer.Address, jailer.CreatedAt)
+		q = q.Values(jailer.ID, jailer.Address, jailer.TxHash, jailer.CreatedAt)
 	}
 	return db.Insert(q)
 }
@@ -32,12 +32,3 @@ func (db DB) GetJailersTotal() (total uint64, err error) {
 	err = db.FindFirst(&total, q)
 	return total, err
 }
-
-func (db DB) GetMostJailedValidators() (items []dmodels.ValidatorValue, err error) {
-	q := squirrel.Select("count() as value", "jlr_address as validator").
-		From(dmodels.JailersTable).
-		GroupBy("validator").
-		OrderBy("value desc")
-	err = db.Find(&items, q)
-	return items, err
-}
@@ -0,0 +1,21 @@
+create table ibc_transfers
+(
+    ibt_id                  FixedString(40),
+    ibt_tx_hash             FixedString(64),
+    ibt_ack_tx_hash         String,
+    ibt_direction           String,
+    ibt_status              String,
+    ibt_source_port         String,
+    ibt_source_channel      String,
+    ibt_destination_port    String,
+    ibt_destination_channel String,
+    ibt_sequence            UInt64,
+    ibt_sender              String,
+    ibt_receiver            String,
+    ibt_denom               String,
+    ibt_amount              Decimal128(18),
+    ibt_created_at          DateTime,
+    ibt_updated_at          DateTime
+) ENGINE ReplacingMergeTree(ibt_updated_at)
+      PARTITION BY toYYYYMM(ibt_created_at)
+      ORDER BY (ibt_id);
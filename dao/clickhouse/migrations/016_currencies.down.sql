@@ -0,0 +1,3 @@
+ALTER TABLE delegator_rewards DROP COLUMN IF EXISTS der_currency;
+ALTER TABLE validator_rewards DROP COLUMN IF EXISTS var_currency;
+ALTER TABLE proposal_deposits DROP COLUMN IF EXISTS prd_currency;
@@ -0,0 +1,3 @@
+ALTER TABLE delegator_rewards ADD COLUMN der_currency String DEFAULT '';
+ALTER TABLE validator_rewards ADD COLUMN var_currency String DEFAULT '';
+ALTER TABLE proposal_deposits ADD COLUMN prd_currency String DEFAULT '';
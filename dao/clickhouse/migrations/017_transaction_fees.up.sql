@@ -0,0 +1,10 @@
+create table transaction_fees
+(
+    txf_id         FixedString(40),
+    txf_tx_hash    FixedString(64),
+    txf_currency   String,
+    txf_amount     Decimal128(18),
+    txf_created_at DateTime
+) ENGINE ReplacingMergeTree()
+      PARTITION BY toYYYYMM(txf_created_at)
+      ORDER BY (txf_id);
@@ -0,0 +1,23 @@
+create table validator_events
+(
+    vle_id                  FixedString(40),
+    vle_tx_hash             String,
+    vle_height              UInt64,
+    vle_type                String,
+    vle_operator_address    String,
+    vle_address             String,
+    vle_cons_address        String,
+    vle_cons_pub_key        String,
+    vle_moniker             String,
+    vle_identity            String,
+    vle_website             String,
+    vle_details             String,
+    vle_commission_rate     Decimal128(18),
+    vle_max_rate            Decimal128(18),
+    vle_max_change_rate     Decimal128(18),
+    vle_min_self_delegation Decimal128(18),
+    vle_self_bond           Decimal128(18),
+    vle_created_at          DateTime
+) ENGINE ReplacingMergeTree()
+      PARTITION BY toYYYYMM(vle_created_at)
+      ORDER BY (vle_id);
@@ -0,0 +1,15 @@
+create table slashes
+(
+    sls_id                FixedString(40),
+    sls_height            UInt64,
+    sls_infraction_height UInt64,
+    sls_validator         String,
+    sls_reason            String,
+    sls_power             UInt64,
+    sls_burned            Decimal128(18),
+    sls_missed_blocks     UInt64,
+    sls_jailed            UInt8,
+    sls_created_at        DateTime
+) ENGINE ReplacingMergeTree()
+      PARTITION BY toYYYYMM(sls_created_at)
+      ORDER BY (sls_id);
@@ -0,0 +1,6 @@
+ALTER TABLE transactions DROP COLUMN IF EXISTS trn_memo;
+ALTER TABLE transactions DROP COLUMN IF EXISTS trn_fee_payer;
+ALTER TABLE transactions DROP COLUMN IF EXISTS trn_fee_granter;
+ALTER TABLE transactions DROP COLUMN IF EXISTS trn_signers;
+ALTER TABLE transactions DROP COLUMN IF EXISTS trn_message_types;
+ALTER TABLE transactions DROP COLUMN IF EXISTS trn_raw_log;
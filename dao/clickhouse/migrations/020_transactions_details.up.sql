@@ -0,0 +1,6 @@
+ALTER TABLE transactions ADD COLUMN trn_memo String DEFAULT '';
+ALTER TABLE transactions ADD COLUMN trn_fee_payer String DEFAULT '';
+ALTER TABLE transactions ADD COLUMN trn_fee_granter String DEFAULT '';
+ALTER TABLE transactions ADD COLUMN trn_signers Array(String);
+ALTER TABLE transactions ADD COLUMN trn_message_types Array(String);
+ALTER TABLE transactions ADD COLUMN trn_raw_log String DEFAULT '';
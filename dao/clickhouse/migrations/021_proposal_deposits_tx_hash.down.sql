@@ -0,0 +1 @@
+ALTER TABLE proposal_deposits DROP COLUMN IF EXISTS prd_tx_hash;
@@ -0,0 +1 @@
+ALTER TABLE proposal_deposits ADD COLUMN prd_tx_hash String DEFAULT '';
@@ -0,0 +1,10 @@
+create table validator_powers
+(
+    vlp_id          FixedString(40),
+    vlp_height      UInt64,
+    vlp_validator   FixedString(40),
+    vlp_power       UInt64,
+    vlp_created_at  DateTime
+) ENGINE ReplacingMergeTree()
+      PARTITION BY toYYYYMM(vlp_created_at)
+      ORDER BY (vlp_id);
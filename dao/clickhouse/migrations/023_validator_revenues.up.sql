@@ -0,0 +1,12 @@
+create table validator_revenues
+(
+    vrv_id              FixedString(40),
+    vrv_height          UInt64,
+    vrv_validator       String,
+    vrv_commission      Decimal128(18),
+    vrv_rewards         Decimal128(18),
+    vrv_proposer_reward Decimal128(18),
+    vrv_created_at      DateTime
+) ENGINE ReplacingMergeTree()
+      PARTITION BY toYYYYMM(vrv_created_at)
+      ORDER BY (vrv_id);
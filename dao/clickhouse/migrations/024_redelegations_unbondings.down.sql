@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS redelegations;
+DROP TABLE IF EXISTS unbondings;
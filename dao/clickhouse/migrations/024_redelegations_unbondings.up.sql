@@ -0,0 +1,28 @@
+create table redelegations
+(
+    red_id              FixedString(40),
+    red_height          UInt64,
+    red_tx_hash         String,
+    red_delegator       String,
+    red_validator_src   String,
+    red_validator_dst   String,
+    red_amount          Decimal128(18),
+    red_completion_time DateTime,
+    red_created_at      DateTime
+) ENGINE ReplacingMergeTree()
+      PARTITION BY toYYYYMM(red_created_at)
+      ORDER BY (red_id);
+
+create table unbondings
+(
+    unb_id              FixedString(40),
+    unb_height          UInt64,
+    unb_tx_hash         String,
+    unb_delegator       String,
+    unb_validator       String,
+    unb_amount          Decimal128(18),
+    unb_completion_time DateTime,
+    unb_created_at      DateTime
+) ENGINE ReplacingMergeTree()
+      PARTITION BY toYYYYMM(unb_created_at)
+      ORDER BY (unb_id);
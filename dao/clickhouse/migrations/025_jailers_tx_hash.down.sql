@@ -0,0 +1 @@
+ALTER TABLE jailers DROP COLUMN IF EXISTS jlr_tx_hash;
@@ -0,0 +1 @@
+ALTER TABLE jailers ADD COLUMN jlr_tx_hash String DEFAULT '';
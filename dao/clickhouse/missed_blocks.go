@@ -31,11 +31,38 @@ func (db DB) CreateMissedBlocks(blocks []dmodels.MissedBlock) error {
 	return db.Insert(q)
 }
 
+// GetMissedBlocksCount returns the number of missed validations, a validation reparsed by reindex is counted once.
 func (db DB) GetMissedBlocksCount(filter filters.MissedBlocks) (total uint64, err error) {
-	q := squirrel.Select("count(*) as total").From(dmodels.MissedBlocks)
+	q := missedBlocksQuery(squirrel.Select("uniq(mib_height, mib_validator) as total").From(dmodels.MissedBlocks), filter)
+	err = db.FindFirst(&total, q)
+	return total, err
+}
+
+// GetMissedBlocksHeights returns the distinct missed heights in ascending order.
+func (db DB) GetMissedBlocksHeights(filter filters.MissedBlocks) (heights []uint64, err error) {
+	q := missedBlocksQuery(squirrel.Select("distinct mib_height").From(dmodels.MissedBlocks), filter).
+		OrderBy("mib_height")
+	err = db.Find(&heights, q)
+	return heights, err
+}
+
+// GetValidatorsMissedBlocks returns the number of missed heights of every validator with missed blocks.
+func (db DB) GetValidatorsMissedBlocks(filter filters.MissedBlocks) (items []dmodels.ValidatorValue, err error) {
+	q := missedBlocksQuery(squirrel.Select("mib_validator as validator", "uniq(mib_height) as value").From(dmodels.MissedBlocks), filter).
+		GroupBy("mib_validator")
+	err = db.Find(&items, q)
+	return items, err
+}
+
+func missedBlocksQuery(q squirrel.SelectBuilder, filter filters.MissedBlocks) squirrel.SelectBuilder {
 	if len(filter.Validators) != 0 {
 		q = q.Where(squirrel.Eq{"mib_validator": filter.Validators})
 	}
-	err = db.FindFirst(&total, q)
-	return total, err
+	if filter.HeightFrom != 0 {
+		q = q.Where(squirrel.GtOrEq{"mib_height": filter.HeightFrom})
+	}
+	if filter.HeightTo != 0 {
+		q = q.Where(squirrel.LtOrEq{"mib_height": filter.HeightTo})
+	}
+	return q
 }
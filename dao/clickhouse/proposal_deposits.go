@@ -12,7 +12,7 @@ func (db DB) CreateProposalDeposits(deposits []dmodels.ProposalDeposit) error {
 	if len(deposits) == 0 {
 		return nil
 	}
-	q := squirrel.Insert(dmodels.ProposalDepositsTable).Columns("prd_id", "prd_proposal_id", "prd_depositor", "prd_amount", "prd_created_at")
+	q := squirrel.Insert(dmodels.ProposalDepositsTable).Columns("prd_id", "prd_proposal_id", "prd_depositor", "prd_amount", "prd_created_at", "prd_currency", "prd_tx_hash")
 	for _, deposit := range deposits {
 		if deposit.ID == "" {
 			return fmt.Errorf("field ProposalID can not be empty")
@@ -23,16 +23,19 @@ func (db DB) CreateProposalDeposits(deposits []dmodels.ProposalDeposit) error {
 		if deposit.CreatedAt.IsZero() {
 			return fmt.Errorf("field CreatedAt can not be zero")
 		}
-		q = q.Values(deposit.ID, deposit.ProposalID, deposit.Depositor, deposit.Amount, deposit.CreatedAt)
+		q = q.Values(deposit.ID, deposit.ProposalID, deposit.Depositor, deposit.Amount, deposit.CreatedAt, deposit.Currency, deposit.TxHash)
 	}
 	return db.Insert(q)
 }
 
 func (db DB) GetProposalDeposits(filter filters.ProposalDeposits) (deposits []dmodels.ProposalDeposit, err error) {
-	q := squirrel.Select("*").From(dmodels.ProposalDepositsTable)
+	q := squirrel.Select("*").From(fmt.Sprintf("%s FINAL", dmodels.ProposalDepositsTable))
 	if len(filter.ProposalID) != 0 {
 		q = q.Where(squirrel.Eq{"prd_proposal_id": filter.ProposalID})
 	}
+	if filter.TxHash != "" {
+		q = q.Where(squirrel.Eq{"prd_tx_hash": filter.TxHash})
+	}
 	err = db.Find(&deposits, q)
 	return deposits, err
 }
@@ -2,6 +2,8 @@ package clickhouse
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/kwanifi/numiscan-api/dao/filters"
@@ -36,13 +38,16 @@ func (db DB) CreateProposalVotes(votes []dmodels.ProposalVote) error {
 }
 
 func (db DB) GetProposalVotes(filter filters.ProposalVotes) (votes []dmodels.ProposalVote, err error) {
-	q := squirrel.Select("*").From(dmodels.ProposalVotesTable).OrderBy("prv_created_at")
+	q := squirrel.Select("*").From(fmt.Sprintf("%s FINAL", dmodels.ProposalVotesTable)).OrderBy("prv_created_at")
 	if filter.ProposalID != 0 {
 		q = q.Where(squirrel.Eq{"prv_proposal_id": filter.ProposalID})
 	}
 	if len(filter.Voters) != 0 {
 		q = q.Where(squirrel.Eq{"prv_voter": filter.Voters})
 	}
+	if filter.TxHash != "" {
+		q = q.Where(squirrel.Eq{"prv_tx_hash": filter.TxHash})
+	}
 	if filter.Limit != 0 {
 		q = q.Limit(filter.Limit)
 	}
@@ -69,3 +74,53 @@ func (db DB) GetTotalVotesByAddress(address string) (total uint64, err error) {
 	err = db.FindFirst(&total, q)
 	return total, err
 }
+
+// GetLastVotes returns the last vote of every voter on every proposal, as a vote can be changed during the voting period.
+func (db DB) GetLastVotes(filter filters.LastVotes) (votes []smodels.LastVote, err error) {
+	q := squirrel.Select(
+		"prv_proposal_id as proposal_id",
+		"prv_voter as voter",
+		"argMax(prv_option, prv_created_at) as option",
+		"argMax(prv_tx_hash, prv_created_at) as tx_hash",
+		"max(prv_created_at) as voted_at",
+	).From(dmodels.ProposalVotesTable).
+		Where(squirrel.Eq{"prv_proposal_id": filter.ProposalIDs}).
+		Where(squirrel.Eq{"prv_voter": filter.Voters}).
+		GroupBy("proposal_id", "voter")
+	err = db.Find(&votes, q)
+	return votes, err
+}
+
+// GetDelegatorOverrides returns the number of the current delegators of the validator who voted on every proposal.
+func (db DB) GetDelegatorOverrides(filter filters.DelegatorOverrides) (items []smodels.ProposalCount, err error) {
+	if len(filter.VotingEndTimes) == 0 {
+		return nil, nil
+	}
+	ids := make([]uint64, 0, len(filter.VotingEndTimes))
+	for id := range filter.VotingEndTimes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	// every delegation is paired with every proposal and counted only when made before its voting end
+	tuples := make([]string, len(ids))
+	args := make([]interface{}, 0, len(ids)*2)
+	for i, id := range ids {
+		tuples[i] = "(?, toDateTime(?))"
+		args = append(args, id, filter.VotingEndTimes[id])
+	}
+	delegators := squirrel.Select("proposal.1 AS proposal_id", "dlg_delegator").
+		From(fmt.Sprintf("%s FINAL", dmodels.DelegationsTable)).
+		JoinClause(fmt.Sprintf("ARRAY JOIN [%s] AS proposal", strings.Join(tuples, ", ")), args...).
+		Where(squirrel.Eq{"dlg_validator": filter.Validator}).
+		Where("dlg_created_at <= proposal.2").
+		GroupBy("proposal_id", "dlg_delegator").
+		Having(squirrel.Gt{"sum(dlg_amount)": 0})
+	q := squirrel.Select("prv_proposal_id as proposal_id", "count(distinct prv_voter) as value").
+		From(fmt.Sprintf("%s FINAL", dmodels.ProposalVotesTable)).
+		Where(squirrel.Eq{"prv_proposal_id": ids}).
+		Where(squirrel.NotEq{"prv_voter": filter.ValidatorAccount}).
+		Where(squirrel.Expr("(prv_proposal_id, prv_voter) IN (?)", delegators)).
+		GroupBy("proposal_id")
+	err = db.Find(&items, q)
+	return items, err
+}
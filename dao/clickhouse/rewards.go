@@ -4,14 +4,16 @@ import (
 	"fmt"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/kwanifi/numiscan-api/dao/filters"
 	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/shopspring/decimal"
 )
 
 func (db DB) CreateDelegatorRewards(rewards []dmodels.DelegatorReward) error {
 	if len(rewards) == 0 {
 		return nil
 	}
-	q := squirrel.Insert(dmodels.DelegatorRewardsTable).Columns("der_id", "der_tx_hash", "der_delegator", "der_validator", "der_amount", "der_created_at")
+	q := squirrel.Insert(dmodels.DelegatorRewardsTable).Columns("der_id", "der_tx_hash", "der_delegator", "der_validator", "der_amount", "der_created_at", "der_currency")
 	for _, reward := range rewards {
 		if reward.ID == "" {
 			return fmt.Errorf("field ProposalID can not be empty")
@@ -28,7 +30,7 @@ func (db DB) CreateDelegatorRewards(rewards []dmodels.DelegatorReward) error {
 		if reward.CreatedAt.IsZero() {
 			return fmt.Errorf("field CreatedAt can not be zero")
 		}
-		q = q.Values(reward.ID, reward.TxHash, reward.Delegator, reward.Validator, reward.Amount, reward.CreatedAt)
+		q = q.Values(reward.ID, reward.TxHash, reward.Delegator, reward.Validator, reward.Amount, reward.CreatedAt, reward.Currency)
 	}
 	return db.Insert(q)
 }
@@ -37,7 +39,7 @@ func (db DB) CreateValidatorRewards(rewards []dmodels.ValidatorReward) error {
 	if len(rewards) == 0 {
 		return nil
 	}
-	q := squirrel.Insert(dmodels.ValidatorRewardsTable).Columns("var_id", "var_tx_hash", "var_address", "var_amount", "var_created_at")
+	q := squirrel.Insert(dmodels.ValidatorRewardsTable).Columns("var_id", "var_tx_hash", "var_address", "var_amount", "var_created_at", "var_currency")
 	for _, reward := range rewards {
 		if reward.ID == "" {
 			return fmt.Errorf("field ProposalID can not be empty")
@@ -51,7 +53,43 @@ func (db DB) CreateValidatorRewards(rewards []dmodels.ValidatorReward) error {
 		if reward.CreatedAt.IsZero() {
 			return fmt.Errorf("field CreatedAt can not be zero")
 		}
-		q = q.Values(reward.ID, reward.TxHash, reward.Address, reward.Amount, reward.CreatedAt)
+		q = q.Values(reward.ID, reward.TxHash, reward.Address, reward.Amount, reward.CreatedAt, reward.Currency)
 	}
 	return db.Insert(q)
 }
+
+func (db DB) GetDelegatorRewards(filter filters.Rewards) (rewards []dmodels.DelegatorReward, err error) {
+	q := squirrel.Select("*").From(fmt.Sprintf("%s FINAL", dmodels.DelegatorRewardsTable)).OrderBy("der_id")
+	q = delegatorRewardsQuery(filter, q)
+	err = db.Find(&rewards, q)
+	return rewards, err
+}
+
+func (db DB) GetDelegatorRewardsVolume(filter filters.Rewards) (total decimal.Decimal, err error) {
+	q := squirrel.Select("sum(der_amount) as total").From(dmodels.DelegatorRewardsTable)
+	q = delegatorRewardsQuery(filter, q)
+	err = db.FindFirst(&total, q)
+	return total, err
+}
+
+func (db DB) GetValidatorRewards(filter filters.Rewards) (rewards []dmodels.ValidatorReward, err error) {
+	q := squirrel.Select("*").From(fmt.Sprintf("%s FINAL", dmodels.ValidatorRewardsTable)).OrderBy("var_id")
+	if filter.TxHash != "" {
+		q = q.Where(squirrel.Eq{"var_tx_hash": filter.TxHash})
+	}
+	err = db.Find(&rewards, q)
+	return rewards, err
+}
+
+func delegatorRewardsQuery(filter filters.Rewards, q squirrel.SelectBuilder) squirrel.SelectBuilder {
+	if filter.TxHash != "" {
+		q = q.Where(squirrel.Eq{"der_tx_hash": filter.TxHash})
+	}
+	if filter.Delegator != "" {
+		q = q.Where(squirrel.Eq{"der_delegator": filter.Delegator})
+	}
+	if filter.Currency != "" {
+		q = q.Where(squirrel.Eq{"der_currency": filter.Currency})
+	}
+	return filter.Query("der_created_at", q)
+}
@@ -0,0 +1,131 @@
+package clickhouse
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/kwanifi/numiscan-api/dmodels"
+)
+
+const (
+	mutationsPollDelay = time.Second
+	mutationsTimeout   = time.Minute * 30
+)
+
+// parsedTables lists the tables filled by the parser. Rows of the rolled back blocks are found
+// by the height column when the table has one, by the hash of the rolled back transactions otherwise.
+// The transactions table is the last, the others look up the rolled back hashes in it.
+var parsedTables = []struct {
+	table    string
+	column   string
+	byHeight bool
+}{
+	{table: dmodels.BlocksTable, column: "blk_id", byHeight: true},
+	{table: dmodels.MissedBlocks, column: "mib_height", byHeight: true},
+	{table: dmodels.ValidatorEventsTable, column: "vle_height", byHeight: true},
+	{table: dmodels.SlashesTable, column: "sls_height", byHeight: true},
+	{table: dmodels.ValidatorPowersTable, column: "vlp_height", byHeight: true},
+	{table: dmodels.ValidatorRevenuesTable, column: "vrv_height", byHeight: true},
+	{table: dmodels.RedelegationsTable, column: "red_height", byHeight: true},
+	{table: dmodels.UnbondingsTable, column: "unb_height", byHeight: true},
+	{table: dmodels.TransactionFeesTable, column: "txf_tx_hash"},
+	{table: dmodels.TransfersTable, column: "trf_tx_hash"},
+	{table: dmodels.DelegationsTable, column: "dlg_tx_hash"},
+	{table: dmodels.DelegatorRewardsTable, column: "der_tx_hash"},
+	{table: dmodels.ValidatorRewardsTable, column: "var_tx_hash"},
+	{table: dmodels.HistoryProposalsTable, column: "hpr_tx_hash"},
+	{table: dmodels.ProposalDepositsTable, column: "prd_tx_hash"},
+	{table: dmodels.ProposalVotesTable, column: "prv_tx_hash"},
+	{table: dmodels.JailersTable, column: "jlr_tx_hash"},
+	{table: dmodels.IBCTransfersTable, column: "ibt_tx_hash"},
+	{table: dmodels.TransactionsTable, column: "trn_height", byHeight: true},
+}
+
+// rolledBackTxs selects the hashes of the transactions above the height.
+var rolledBackTxs = fmt.Sprintf("SELECT trn_hash FROM %s WHERE trn_height > ?", dmodels.TransactionsTable)
+
+// DeleteBlocksAbove deletes everything parsed after the given height.
+// Every delete is waited for, so the blocks parsed again are not removed by a mutation still running.
+func (db DB) DeleteBlocksAbove(height uint64) error {
+	err := db.resetIBCTransfersAbove(height)
+	if err != nil {
+		return fmt.Errorf("resetIBCTransfersAbove: %s", err.Error())
+	}
+	for _, t := range parsedTables {
+		condition := fmt.Sprintf("%s > ?", t.column)
+		if !t.byHeight {
+			condition = fmt.Sprintf("%s IN (%s)", t.column, rolledBackTxs)
+		}
+		err = db.deleteSync(t.table, condition, height)
+		if err != nil {
+			return fmt.Errorf("%s: %s", t.table, err.Error())
+		}
+	}
+	return nil
+}
+
+// resetIBCTransfersAbove returns the transfers sent at or below the height and acknowledged or timed out
+// above it to their state before the acknowledgement. The transfers sent above it are deleted with the other rows.
+func (db DB) resetIBCTransfersAbove(height uint64) error {
+	condition := fmt.Sprintf("ibt_ack_tx_hash IN (%s) AND ibt_tx_hash NOT IN (%s)", rolledBackTxs, rolledBackTxs)
+	var transfers []dmodels.IBCTransfer
+	err := db.Find(&transfers, squirrel.Select("*").From(fmt.Sprintf("%s FINAL", dmodels.IBCTransfersTable)).
+		Where(condition, height, height))
+	if err != nil {
+		return fmt.Errorf("find: %s", err.Error())
+	}
+	if len(transfers) == 0 {
+		return nil
+	}
+	err = db.deleteSync(dmodels.IBCTransfersTable, condition, height, height)
+	if err != nil {
+		return fmt.Errorf("delete: %s", err.Error())
+	}
+	for i, transfer := range transfers {
+		transfers[i].AckTxHash = ""
+		transfers[i].Status = dmodels.IBCTransferStatusPending
+		if transfer.Direction == dmodels.IBCTransferDirectionIn {
+			transfers[i].Status = dmodels.IBCTransferStatusReceived
+		}
+		transfers[i].UpdatedAt = transfer.CreatedAt
+	}
+	return db.CreateIBCTransfers(transfers)
+}
+
+// deleteSync deletes the rows of the table and waits for the mutation to finish.
+func (db DB) deleteSync(table string, condition string, args ...interface{}) error {
+	_, err := db.conn.Exec(fmt.Sprintf("ALTER TABLE %s DELETE WHERE %s", table, condition), args...)
+	if err != nil {
+		return err
+	}
+	return db.waitMutations(table)
+}
+
+// waitMutations blocks until the mutations of the table are done.
+func (db DB) waitMutations(table string) error {
+	deadline := time.Now().Add(mutationsTimeout)
+	for {
+		var mutations []struct {
+			ID         string `db:"mutation_id"`
+			FailReason string `db:"latest_fail_reason"`
+		}
+		err := db.conn.Select(&mutations, "SELECT mutation_id, latest_fail_reason FROM system.mutations "+
+			"WHERE database = currentDatabase() AND table = ? AND is_done = 0", table)
+		if err != nil {
+			return fmt.Errorf("system.mutations: %s", err.Error())
+		}
+		if len(mutations) == 0 {
+			return nil
+		}
+		for _, m := range mutations {
+			if m.FailReason != "" {
+				return fmt.Errorf("mutation %s: %s", m.ID, m.FailReason)
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("mutation %s is not done in %s", mutations[0].ID, mutationsTimeout)
+		}
+		time.Sleep(mutationsPollDelay)
+	}
+}
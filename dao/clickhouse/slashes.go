@@ -0,0 +1,95 @@
+package clickhouse
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/dmodels"
+)
+
+func (db DB) CreateSlashes(slashes []dmodels.Slash) error {
+	if len(slashes) == 0 {
+		return nil
+	}
+	q := squirrel.Insert(dmodels.SlashesTable).Columns(
+		"sls_id",
+		"sls_height",
+		"sls_infraction_height",
+		"sls_validator",
+		"sls_reason",
+		"sls_power",
+		"sls_burned",
+		"sls_missed_blocks",
+		"sls_jailed",
+		"sls_created_at",
+	)
+	for _, slash := range slashes {
+		if slash.ID == "" {
+			return fmt.Errorf("field ID can not be empty")
+		}
+		if slash.Height == 0 {
+			return fmt.Errorf("field Height can not be zero")
+		}
+		if slash.Validator == "" {
+			return fmt.Errorf("field Validator can not be empty")
+		}
+		if slash.CreatedAt.IsZero() {
+			return fmt.Errorf("field CreatedAt can not be zero")
+		}
+		q = q.Values(
+			slash.ID,
+			slash.Height,
+			slash.InfractionHeight,
+			slash.Validator,
+			slash.Reason,
+			slash.Power,
+			slash.Burned,
+			slash.MissedBlocks,
+			slash.Jailed,
+			slash.CreatedAt,
+		)
+	}
+	return db.Insert(q)
+}
+
+func (db DB) GetSlashes(filter filters.Slashes) (slashes []dmodels.Slash, err error) {
+	q := squirrel.Select("*").From(fmt.Sprintf("%s FINAL", dmodels.SlashesTable)).OrderBy("sls_height desc")
+	q = slashesQuery(filter, q)
+	if filter.Limit != 0 {
+		q = q.Limit(filter.Limit)
+	}
+	if filter.Offset != 0 {
+		q = q.Offset(filter.Offset)
+	}
+	err = db.Find(&slashes, q)
+	return slashes, err
+}
+
+func (db DB) GetSlashesTotal(filter filters.Slashes) (total uint64, err error) {
+	q := squirrel.Select("count(*) as total").From(fmt.Sprintf("%s FINAL", dmodels.SlashesTable))
+	q = slashesQuery(filter, q)
+	err = db.FindFirst(&total, q)
+	return total, err
+}
+
+// GetMostJailedValidators counts the jails of the validators, the validator is the hex consensus address.
+func (db DB) GetMostJailedValidators() (items []dmodels.ValidatorValue, err error) {
+	q := squirrel.Select("count() as value", "sls_validator as validator").
+		From(fmt.Sprintf("%s FINAL", dmodels.SlashesTable)).
+		Where(squirrel.Eq{"sls_jailed": 1}).
+		GroupBy("validator").
+		OrderBy("value desc")
+	err = db.Find(&items, q)
+	return items, err
+}
+
+func slashesQuery(filter filters.Slashes, q squirrel.SelectBuilder) squirrel.SelectBuilder {
+	if len(filter.Validators) != 0 {
+		q = q.Where(squirrel.Eq{"sls_validator": filter.Validators})
+	}
+	if filter.Reason != "" {
+		q = q.Where(squirrel.Eq{"sls_reason": filter.Reason})
+	}
+	return q
+}
@@ -0,0 +1,145 @@
+package clickhouse
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/kwanifi/numiscan-api/smodels"
+)
+
+func (db DB) CreateRedelegations(redelegations []dmodels.Redelegation) error {
+	if len(redelegations) == 0 {
+		return nil
+	}
+	q := squirrel.Insert(dmodels.RedelegationsTable).Columns(
+		"red_id",
+		"red_height",
+		"red_tx_hash",
+		"red_delegator",
+		"red_validator_src",
+		"red_validator_dst",
+		"red_amount",
+		"red_completion_time",
+		"red_created_at",
+	)
+	for _, redelegation := range redelegations {
+		if redelegation.ID == "" {
+			return fmt.Errorf("field ID can not be empty")
+		}
+		if redelegation.TxHash == "" {
+			return fmt.Errorf("field TxHash can not be empty")
+		}
+		if redelegation.ValidatorSrc == "" || redelegation.ValidatorDst == "" {
+			return fmt.Errorf("fields ValidatorSrc and ValidatorDst can not be empty")
+		}
+		if redelegation.CreatedAt.IsZero() {
+			return fmt.Errorf("field CreatedAt can not be 0")
+		}
+		q = q.Values(
+			redelegation.ID,
+			redelegation.Height,
+			redelegation.TxHash,
+			redelegation.Delegator,
+			redelegation.ValidatorSrc,
+			redelegation.ValidatorDst,
+			redelegation.Amount,
+			redelegation.CompletionTime,
+			redelegation.CreatedAt,
+		)
+	}
+	return db.Insert(q)
+}
+
+func (db DB) CreateUnbondings(unbondings []dmodels.Unbonding) error {
+	if len(unbondings) == 0 {
+		return nil
+	}
+	q := squirrel.Insert(dmodels.UnbondingsTable).Columns(
+		"unb_id",
+		"unb_height",
+		"unb_tx_hash",
+		"unb_delegator",
+		"unb_validator",
+		"unb_amount",
+		"unb_completion_time",
+		"unb_created_at",
+	)
+	for _, unbonding := range unbondings {
+		if unbonding.ID == "" {
+			return fmt.Errorf("field ID can not be empty")
+		}
+		if unbonding.TxHash == "" {
+			return fmt.Errorf("field TxHash can not be empty")
+		}
+		if unbonding.Validator == "" {
+			return fmt.Errorf("field Validator can not be empty")
+		}
+		if unbonding.CreatedAt.IsZero() {
+			return fmt.Errorf("field CreatedAt can not be 0")
+		}
+		q = q.Values(
+			unbonding.ID,
+			unbonding.Height,
+			unbonding.TxHash,
+			unbonding.Delegator,
+			unbonding.Validator,
+			unbonding.Amount,
+			unbonding.CompletionTime,
+			unbonding.CreatedAt,
+		)
+	}
+	return db.Insert(q)
+}
+
+// GetAggUnbondingChanges returns the change of the unbonding queue per period up to now over the whole history:
+// the undelegated tokens enter the queue at the creation time and leave it at the completion time.
+func (db DB) GetAggUnbondingChanges(filter filters.Agg) (items []smodels.AggItem, err error) {
+	query := fmt.Sprintf(`SELECT sum(delta) AS value, toDateTime(%s(t)) AS time FROM (
+		SELECT unb_created_at AS t, unb_amount AS delta FROM %s FINAL
+		UNION ALL
+		SELECT unb_completion_time AS t, -unb_amount AS delta FROM %s FINAL
+	) WHERE t <= ? GROUP BY time ORDER BY time`, filter.AggFunc(), dmodels.UnbondingsTable, dmodels.UnbondingsTable)
+	err = db.conn.Select(&items, query, time.Now())
+	return items, err
+}
+
+// GetUnbondingSchedule returns the tokens unlocking per day from now on.
+func (db DB) GetUnbondingSchedule(filter filters.UnbondingSchedule) (items []smodels.AggItem, err error) {
+	q := squirrel.Select("sum(unb_amount) as value", "toDateTime(toStartOfDay(unb_completion_time)) as time").
+		From(dmodels.UnbondingsTable).
+		Where(squirrel.Gt{"unb_completion_time": time.Now()}).
+		GroupBy("time").
+		OrderBy("time")
+	if filter.Validator != "" {
+		q = q.Where(squirrel.Eq{"unb_validator": filter.Validator})
+	}
+	if filter.Delegator != "" {
+		q = q.Where(squirrel.Eq{"unb_delegator": filter.Delegator})
+	}
+	err = db.Find(&items, q)
+	return items, err
+}
+
+// GetRedelegationFlows returns the redelegated stake per pair of the source and the destination validators.
+func (db DB) GetRedelegationFlows(filter filters.RedelegationFlows) (items []smodels.RedelegationFlow, err error) {
+	q := squirrel.Select(
+		"red_validator_src as source",
+		"red_validator_dst as destination",
+		"sum(red_amount) as amount",
+		"count() as count",
+	).From(dmodels.RedelegationsTable).
+		GroupBy("source", "destination").
+		OrderBy("amount desc")
+	if filter.Validator != "" {
+		q = q.Where(squirrel.Or{
+			squirrel.Eq{"red_validator_src": filter.Validator},
+			squirrel.Eq{"red_validator_dst": filter.Validator},
+		})
+	}
+	q = filter.Query("red_created_at", q)
+	err = db.Find(&items, q)
+	return items, err
+}
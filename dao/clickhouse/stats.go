@@ -57,3 +57,10 @@ func (db DB) GetAggWhaleAccounts(filter filters.Agg) (items []smodels.AggItem, e
 	err = db.Find(&items, q)
 	return items, err
 }
+
+func (db DB) GetAggStatsByTitle(filter filters.Agg, title string) (items []smodels.AggItem, err error) {
+	q := filter.BuildQuery("max(stt_value)", "stt_created_at", dmodels.StatsTable).
+		Where(squirrel.Eq{"stt_title": title})
+	err = db.Find(&items, q)
+	return items, err
+}
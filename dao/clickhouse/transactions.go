@@ -7,6 +7,7 @@ import (
 	"github.com/kwanifi/numiscan-api/dao/filters"
 	"github.com/kwanifi/numiscan-api/dmodels"
 	"github.com/kwanifi/numiscan-api/smodels"
+	"github.com/mailru/go-clickhouse"
 	"github.com/shopspring/decimal"
 )
 
@@ -22,6 +23,12 @@ func (db DB) CreateTransactions(transactions []dmodels.Transaction) error {
 		"trn_fee",
 		"trn_gas_used",
 		"trn_gas_wanted",
+		"trn_memo",
+		"trn_fee_payer",
+		"trn_fee_granter",
+		"trn_signers",
+		"trn_message_types",
+		"trn_raw_log",
 		"trn_created_at",
 	)
 	for _, tx := range transactions {
@@ -42,14 +49,89 @@ func (db DB) CreateTransactions(transactions []dmodels.Transaction) error {
 			tx.Fee,
 			tx.GasUsed,
 			tx.GasWanted,
+			tx.Memo,
+			tx.FeePayer,
+			tx.FeeGranter,
+			clickhouse.Array(tx.Signers),
+			clickhouse.Array(tx.MessageTypes),
+			tx.RawLog,
 			tx.CreatedAt,
 		)
 	}
 	return db.Insert(q)
 }
 
+func (db DB) CreateTransactionFees(fees []dmodels.TransactionFee) error {
+	if len(fees) == 0 {
+		return nil
+	}
+	q := squirrel.Insert(dmodels.TransactionFeesTable).Columns("txf_id", "txf_tx_hash", "txf_currency", "txf_amount", "txf_created_at")
+	for _, fee := range fees {
+		if fee.ID == "" {
+			return fmt.Errorf("field ID can not be empty")
+		}
+		if fee.TxHash == "" {
+			return fmt.Errorf("field TxHash can not be empty")
+		}
+		if fee.Currency == "" {
+			return fmt.Errorf("field Currency can not be empty")
+		}
+		if fee.CreatedAt.IsZero() {
+			return fmt.Errorf("field CreatedAt can not be zero")
+		}
+		q = q.Values(fee.ID, fee.TxHash, fee.Currency, fee.Amount, fee.CreatedAt)
+	}
+	return db.Insert(q)
+}
+
+func (db DB) GetTransactions(filter filters.Transactions) (transactions []dmodels.Transaction, err error) {
+	q := squirrel.Select("*").From(fmt.Sprintf("%s FINAL", dmodels.TransactionsTable)).OrderBy("trn_height desc", "trn_hash")
+	q = transactionsQuery(filter, q)
+	if filter.Limit != 0 {
+		q = q.Limit(filter.Limit)
+	}
+	if filter.Offset != 0 {
+		q = q.Offset(filter.Offset)
+	}
+	err = db.Find(&transactions, q)
+	return transactions, err
+}
+
+func (db DB) GetTransactionsTotal(filter filters.Transactions) (total uint64, err error) {
+	q := squirrel.Select("count(*) as total").From(fmt.Sprintf("%s FINAL", dmodels.TransactionsTable))
+	q = transactionsQuery(filter, q)
+	err = db.FindFirst(&total, q)
+	return total, err
+}
+
+func transactionsQuery(filter filters.Transactions, q squirrel.SelectBuilder) squirrel.SelectBuilder {
+	if len(filter.Hash) != 0 {
+		q = q.Where(squirrel.Eq{"trn_hash": filter.Hash})
+	}
+	if filter.Height != 0 {
+		q = q.Where(squirrel.Eq{"trn_height": filter.Height})
+	}
+	switch filter.Status {
+	case dmodels.TransactionStatusSuccess:
+		q = q.Where(squirrel.Eq{"trn_status": true})
+	case dmodels.TransactionStatusFailed:
+		q = q.Where(squirrel.Eq{"trn_status": false})
+	}
+	if filter.MessageType != "" {
+		q = q.Where("has(trn_message_types, ?)", filter.MessageType)
+	}
+	return q
+}
+
 func (db DB) GetAggTransactionsFee(filter filters.Agg) (items []smodels.AggItem, err error) {
-	q := filter.BuildQuery("sum(trn_fee)", "trn_created_at", dmodels.TransactionsTable)
+	// fees in the staking denom are kept in the transactions table as well
+	if filter.Denom == "" {
+		q := filter.BuildQuery("sum(trn_fee)", "trn_created_at", dmodels.TransactionsTable)
+		err = db.Find(&items, q)
+		return items, err
+	}
+	q := filter.BuildQuery("sum(txf_amount)", "txf_created_at", dmodels.TransactionFeesTable).
+		Where(squirrel.Eq{"txf_currency": filter.Denom})
 	err = db.Find(&items, q)
 	return items, err
 }
@@ -60,6 +142,33 @@ func (db DB) GetAggOperationsCount(filter filters.Agg) (items []smodels.AggItem,
 	return items, err
 }
 
+// GetAggFailedTransactionsRate returns the percent of failed transactions.
+func (db DB) GetAggFailedTransactionsRate(filter filters.Agg) (items []smodels.AggItem, err error) {
+	q := filter.BuildQuery("toDecimal64(countIf(trn_status = 0) * 100 / count(), 2)", "trn_created_at", dmodels.TransactionsTable)
+	err = db.Find(&items, q)
+	return items, err
+}
+
+// GetAggMessageTypes returns the most used message types of every period.
+func (db DB) GetAggMessageTypes(filter filters.MessageTypesAgg) (items []smodels.MessageTypeAggItem, err error) {
+	q := squirrel.Select(
+		"count() AS value",
+		fmt.Sprintf("toDateTime(%s(trn_created_at)) AS time", filter.AggFunc()),
+		"arrayJoin(trn_message_types) AS type",
+	).From(dmodels.TransactionsTable).
+		GroupBy("time", "type").
+		OrderBy("time", "value desc").
+		Suffix(fmt.Sprintf("LIMIT %d BY time", filter.Limit))
+	if !filter.From.IsZero() {
+		q = q.Where(squirrel.GtOrEq{"trn_created_at": filter.From.Time})
+	}
+	if !filter.To.IsZero() {
+		q = q.Where(squirrel.LtOrEq{"trn_created_at": filter.To.Time})
+	}
+	err = db.Find(&items, q)
+	return items, err
+}
+
 func (db DB) GetTransactionsFeeVolume(filter filters.TimeRange) (total decimal.Decimal, err error) {
 	q := squirrel.Select("sum(trn_fee) as total").From(dmodels.TransactionsTable)
 	q = filter.Query("trn_created_at", q)
@@ -30,13 +30,22 @@ func (db DB) CreateTransfers(transfers []dmodels.Transfer) error {
 	return db.Insert(q)
 }
 
+func (db DB) GetTransfers(filter filters.Transfers) (transfers []dmodels.Transfer, err error) {
+	q := squirrel.Select("*").From(fmt.Sprintf("%s FINAL", dmodels.TransfersTable)).OrderBy("trf_id")
+	if filter.TxHash != "" {
+		q = q.Where(squirrel.Eq{"trf_tx_hash": filter.TxHash})
+	}
+	err = db.Find(&transfers, q)
+	return transfers, err
+}
+
 func (db DB) GetAggTransfersVolume(filter filters.Agg) (items []smodels.AggItem, err error) {
 	q := squirrel.Select(
 		"sum(trf_amount) AS value",
 		fmt.Sprintf("toDateTime(%s(trf_created_at)) AS time", filter.AggFunc()),
 	).From(dmodels.TransfersTable).
 		Where("notEmpty(trf_from)").
-		Where(squirrel.Eq{"trf_currency": dmodels.CosmosCurrency}).
+		Where(squirrel.Eq{"trf_currency": filter.Denom}).
 		GroupBy("time").
 		OrderBy("time")
 	if !filter.From.IsZero() {
@@ -49,11 +58,11 @@ func (db DB) GetAggTransfersVolume(filter filters.Agg) (items []smodels.AggItem,
 	return items, err
 }
 
-func (db DB) GetTransferVolume(filter filters.TimeRange) (total decimal.Decimal, err error) {
+func (db DB) GetTransferVolume(filter filters.TimeRange, currency string) (total decimal.Decimal, err error) {
 	q := squirrel.Select("sum(trf_amount) as total").
 		From(dmodels.TransfersTable).
 		Where("notEmpty(trf_from)").
-		Where(squirrel.Eq{"trf_currency": dmodels.CosmosCurrency})
+		Where(squirrel.Eq{"trf_currency": currency})
 	q = filter.Query("trf_created_at", q)
 	err = db.FindFirst(&total, q)
 	return total, err
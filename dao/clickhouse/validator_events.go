@@ -0,0 +1,104 @@
+package clickhouse
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/dmodels"
+)
+
+func (db DB) CreateValidatorEvents(events []dmodels.ValidatorEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	q := squirrel.Insert(dmodels.ValidatorEventsTable).Columns(
+		"vle_id",
+		"vle_tx_hash",
+		"vle_height",
+		"vle_type",
+		"vle_operator_address",
+		"vle_address",
+		"vle_cons_address",
+		"vle_cons_pub_key",
+		"vle_moniker",
+		"vle_identity",
+		"vle_website",
+		"vle_details",
+		"vle_commission_rate",
+		"vle_max_rate",
+		"vle_max_change_rate",
+		"vle_min_self_delegation",
+		"vle_self_bond",
+		"vle_created_at",
+	)
+	for _, event := range events {
+		if event.ID == "" {
+			return fmt.Errorf("field ID can not be empty")
+		}
+		if event.TxHash == "" {
+			return fmt.Errorf("field TxHash can not be empty")
+		}
+		if event.Type == "" {
+			return fmt.Errorf("field Type can not be empty")
+		}
+		if event.OperatorAddress == "" {
+			return fmt.Errorf("field OperatorAddress can not be empty")
+		}
+		if event.CreatedAt.IsZero() {
+			return fmt.Errorf("field CreatedAt can not be zero")
+		}
+		q = q.Values(
+			event.ID,
+			event.TxHash,
+			event.Height,
+			event.Type,
+			event.OperatorAddress,
+			event.Address,
+			event.ConsAddress,
+			event.ConsPubKey,
+			event.Moniker,
+			event.Identity,
+			event.Website,
+			event.Details,
+			event.CommissionRate,
+			event.MaxRate,
+			event.MaxChangeRate,
+			event.MinSelfDelegation,
+			event.SelfBond,
+			event.CreatedAt,
+		)
+	}
+	return db.Insert(q)
+}
+
+func (db DB) GetValidatorEvents(filter filters.ValidatorEvents) (events []dmodels.ValidatorEvent, err error) {
+	q := squirrel.Select("*").From(fmt.Sprintf("%s FINAL", dmodels.ValidatorEventsTable)).
+		OrderBy("vle_height desc", "vle_id desc")
+	q = validatorEventsQuery(filter, q)
+	if filter.Limit != 0 {
+		q = q.Limit(filter.Limit)
+	}
+	if filter.Offset != 0 {
+		q = q.Offset(filter.Offset)
+	}
+	err = db.Find(&events, q)
+	return events, err
+}
+
+func (db DB) GetValidatorEventsTotal(filter filters.ValidatorEvents) (total uint64, err error) {
+	q := squirrel.Select("count(*) as total").From(fmt.Sprintf("%s FINAL", dmodels.ValidatorEventsTable))
+	q = validatorEventsQuery(filter, q)
+	err = db.FindFirst(&total, q)
+	return total, err
+}
+
+func validatorEventsQuery(filter filters.ValidatorEvents, q squirrel.SelectBuilder) squirrel.SelectBuilder {
+	if filter.Validator != "" {
+		q = q.Where(squirrel.Eq{"vle_operator_address": filter.Validator})
+	}
+	if filter.BeforeHeight != 0 {
+		q = q.Where(squirrel.Lt{"vle_height": filter.BeforeHeight})
+	}
+	return q
+}
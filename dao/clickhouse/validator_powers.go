@@ -0,0 +1,68 @@
+package clickhouse
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/dmodels"
+)
+
+func (db DB) CreateValidatorPowers(powers []dmodels.ValidatorPower) error {
+	if len(powers) == 0 {
+		return nil
+	}
+	q := squirrel.Insert(dmodels.ValidatorPowersTable).Columns("vlp_id", "vlp_height", "vlp_validator", "vlp_power", "vlp_created_at")
+	for _, power := range powers {
+		if power.ID == "" {
+			return fmt.Errorf("field ID can not be empty")
+		}
+		if power.Height == 0 {
+			return fmt.Errorf("field Height can not be zero")
+		}
+		if power.Validator == "" {
+			return fmt.Errorf("field Validator can not be empty")
+		}
+		if power.CreatedAt.IsZero() {
+			return fmt.Errorf("field CreatedAt can not be 0")
+		}
+		q = q.Values(power.ID, power.Height, power.Validator, power.Power, power.CreatedAt)
+	}
+	return db.Insert(q)
+}
+
+// GetValidatorPowers returns the power of every validator of the set at the height or time of the filter.
+func (db DB) GetValidatorPowers(filter filters.ValidatorPowers) (items []dmodels.ValidatorValue, err error) {
+	q := squirrel.Select("vlp_validator as validator", "argMax(vlp_power, vlp_height) as value").
+		From(dmodels.ValidatorPowersTable).
+		GroupBy("vlp_validator").
+		Having(squirrel.Gt{"value": 0}).
+		OrderBy("value desc")
+	if len(filter.Validators) != 0 {
+		q = q.Where(squirrel.Eq{"vlp_validator": filter.Validators})
+	}
+	if filter.Height != 0 {
+		q = q.Where(squirrel.LtOrEq{"vlp_height": filter.Height})
+	}
+	if !filter.At.IsZero() {
+		q = q.Where(squirrel.LtOrEq{"vlp_created_at": filter.At.Time})
+	}
+	err = db.Find(&items, q)
+	return items, err
+}
+
+// GetValidatorPowerChanges returns the power changes ordered by height.
+func (db DB) GetValidatorPowerChanges(filter filters.ValidatorPowerChanges) (items []dmodels.ValidatorPower, err error) {
+	q := squirrel.Select("*").From(fmt.Sprintf("%s FINAL", dmodels.ValidatorPowersTable)).OrderBy("vlp_height")
+	if len(filter.Validators) != 0 {
+		q = q.Where(squirrel.Eq{"vlp_validator": filter.Validators})
+	}
+	if filter.HeightFrom != 0 {
+		q = q.Where(squirrel.GtOrEq{"vlp_height": filter.HeightFrom})
+	}
+	if filter.HeightTo != 0 {
+		q = q.Where(squirrel.LtOrEq{"vlp_height": filter.HeightTo})
+	}
+	err = db.Find(&items, q)
+	return items, err
+}
@@ -0,0 +1,87 @@
+package clickhouse
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/kwanifi/numiscan-api/smodels"
+	"github.com/shopspring/decimal"
+)
+
+func (db DB) CreateValidatorRevenues(revenues []dmodels.ValidatorRevenue) error {
+	if len(revenues) == 0 {
+		return nil
+	}
+	q := squirrel.Insert(dmodels.ValidatorRevenuesTable).Columns(
+		"vrv_id",
+		"vrv_height",
+		"vrv_validator",
+		"vrv_commission",
+		"vrv_rewards",
+		"vrv_proposer_reward",
+		"vrv_created_at",
+	)
+	for _, revenue := range revenues {
+		if revenue.ID == "" {
+			return fmt.Errorf("field ID can not be empty")
+		}
+		if revenue.Height == 0 {
+			return fmt.Errorf("field Height can not be zero")
+		}
+		if revenue.Validator == "" {
+			return fmt.Errorf("field Validator can not be empty")
+		}
+		if revenue.CreatedAt.IsZero() {
+			return fmt.Errorf("field CreatedAt can not be 0")
+		}
+		q = q.Values(
+			revenue.ID,
+			revenue.Height,
+			revenue.Validator,
+			revenue.Commission,
+			revenue.Rewards,
+			revenue.ProposerReward,
+			revenue.CreatedAt,
+		)
+	}
+	return db.Insert(q)
+}
+
+func (db DB) GetValidatorCommissionVolume(filter filters.ValidatorRevenues) (total decimal.Decimal, err error) {
+	q := squirrel.Select("sum(vrv_commission) as total").From(fmt.Sprintf("%s FINAL", dmodels.ValidatorRevenuesTable))
+	if filter.Validator != "" {
+		q = q.Where(squirrel.Eq{"vrv_validator": filter.Validator})
+	}
+	q = filter.Query("vrv_created_at", q)
+	err = db.FindFirst(&total, q)
+	return total, err
+}
+
+func (db DB) GetAggValidatorRevenues(filter filters.ValidatorRevenueAgg) (items []smodels.ValidatorRevenueAggItem, err error) {
+	q := squirrel.Select(
+		fmt.Sprintf("toDateTime(%s(vrv_created_at)) AS time", filter.AggFunc()),
+		"sum(vrv_commission) AS commission",
+		"sum(vrv_rewards) AS rewards",
+		"sum(vrv_proposer_reward) AS proposer_reward",
+	).From(fmt.Sprintf("%s FINAL", dmodels.ValidatorRevenuesTable)).
+		Where(squirrel.Eq{"vrv_validator": filter.Validator}).
+		GroupBy("time").
+		OrderBy("time")
+	if !filter.From.IsZero() {
+		q = q.Where(squirrel.GtOrEq{"vrv_created_at": filter.From.Time})
+	}
+	if !filter.To.IsZero() {
+		q = q.Where(squirrel.LtOrEq{"vrv_created_at": filter.To.Time})
+	}
+	err = db.Find(&items, q)
+	return items, err
+}
+
+func (db DB) GetAggValidatorCommissionWithdrawals(filter filters.ValidatorRevenueAgg, currency string) (items []smodels.AggItem, err error) {
+	q := filter.BuildQuery("sum(var_amount)", "var_created_at", dmodels.ValidatorRewardsTable).
+		Where(squirrel.Eq{"var_address": filter.Validator, "var_currency": currency})
+	err = db.Find(&items, q)
+	return items, err
+}
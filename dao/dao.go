@@ -23,14 +23,23 @@ type (
 	Mysql interface {
 		GetParsers() (parsers []dmodels.Parser, err error)
 		GetParser(title string) (parser dmodels.Parser, err error)
+		CreateParser(parser dmodels.Parser) error
 		UpdateParser(parser dmodels.Parser) error
 		CreateValidators(validators []dmodels.Validator) error
 		UpdateValidators(validator dmodels.Validator) error
+		GetValidator(consAddress string) (validator dmodels.Validator, err error)
 		CreateAccounts(accounts []dmodels.Account) error
 		UpdateAccount(account dmodels.Account) error
 		GetAccount(address string) (account dmodels.Account, err error)
 		GetAccounts(filter filters.Accounts) (accounts []dmodels.Account, err error)
 		GetAccountsTotal(filter filters.Accounts) (total uint64, err error)
+		GetTopAccounts(filter filters.TopAccounts) (accounts []dmodels.Account, err error)
+		GetAccountsDistribution(bounds []decimal.Decimal) (ranges []dmodels.AccountsRange, err error)
+		GetAccountsHoldings() (holdings dmodels.AccountsHoldings, err error)
+		GetTopAccountsAmount(limit uint64) (amount decimal.Decimal, err error)
+		CreateDirtyAccounts(accounts []dmodels.DirtyAccount) error
+		GetDirtyAccounts(filter filters.DirtyAccounts) (accounts []dmodels.DirtyAccount, err error)
+		DeleteDirtyAccounts(accounts []dmodels.DirtyAccount) error
 		CreateProposals(proposals []dmodels.Proposal) error
 		GetProposals(filter filters.Proposals) (proposals []dmodels.Proposal, err error)
 		UpdateProposal(proposal dmodels.Proposal) error
@@ -38,38 +47,60 @@ type (
 	Clickhouse interface {
 		CreateBlocks(blocks []dmodels.Block) error
 		GetBlocks(filter filters.Blocks) (blocks []dmodels.Block, err error)
+		GetBlocksTotal(filter filters.Blocks) (total uint64, err error)
 		GetAggBlocksCount(filter filters.Agg) (items []smodels.AggItem, err error)
 		GetAggBlocksDelay(filter filters.Agg) (items []smodels.AggItem, err error)
 		GetAvgBlocksDelay(filter filters.TimeRange) (delay float64, err error)
 		GetAggUniqBlockValidators(filter filters.Agg) (items []smodels.AggItem, err error)
 		CreateTransactions(transactions []dmodels.Transaction) error
+		CreateTransactionFees(fees []dmodels.TransactionFee) error
+		GetTransactions(filter filters.Transactions) (transactions []dmodels.Transaction, err error)
+		GetTransactionsTotal(filter filters.Transactions) (total uint64, err error)
 		GetAggOperationsCount(filter filters.Agg) (items []smodels.AggItem, err error)
+		GetAggFailedTransactionsRate(filter filters.Agg) (items []smodels.AggItem, err error)
+		GetAggMessageTypes(filter filters.MessageTypesAgg) (items []smodels.MessageTypeAggItem, err error)
 		GetAggTransactionsFee(filter filters.Agg) (items []smodels.AggItem, err error)
 		GetTransactionsFeeVolume(filter filters.TimeRange) (total decimal.Decimal, err error)
 		GetTransactionsHighestFee(filter filters.TimeRange) (total decimal.Decimal, err error)
 		GetAggTransfersVolume(filter filters.Agg) (items []smodels.AggItem, err error)
 		CreateTransfers(transfers []dmodels.Transfer) error
-		GetTransferVolume(filter filters.TimeRange) (total decimal.Decimal, err error)
+		GetTransfers(filter filters.Transfers) (transfers []dmodels.Transfer, err error)
+		GetTransferVolume(filter filters.TimeRange, currency string) (total decimal.Decimal, err error)
 		CreateDelegations(delegations []dmodels.Delegation) error
+		GetDelegations(filter filters.Delegations) (delegations []dmodels.Delegation, err error)
+		GetAccountDelegations(address string) (items []smodels.AccountDelegation, err error)
 		GetAggDelegationsVolume(filter filters.DelegationsAgg) (items []smodels.AggItem, err error)
 		GetUndelegationsVolume(filter filters.TimeRange) (total decimal.Decimal, err error)
 		GetDelegatorsTotal(filter filters.Delegators) (total uint64, err error)
 		GetMultiDelegatorsTotal(filter filters.TimeRange) (total uint64, err error)
 		GetAggUndelegationsVolume(filter filters.Agg) (items []smodels.AggItem, err error)
+		CreateRedelegations(redelegations []dmodels.Redelegation) error
+		CreateUnbondings(unbondings []dmodels.Unbonding) error
+		GetAggUnbondingChanges(filter filters.Agg) (items []smodels.AggItem, err error)
+		GetUnbondingSchedule(filter filters.UnbondingSchedule) (items []smodels.AggItem, err error)
+		GetRedelegationFlows(filter filters.RedelegationFlows) (items []smodels.RedelegationFlow, err error)
 		CreateDelegatorRewards(rewards []dmodels.DelegatorReward) error
 		CreateValidatorRewards(rewards []dmodels.ValidatorReward) error
+		GetDelegatorRewards(filter filters.Rewards) (rewards []dmodels.DelegatorReward, err error)
+		GetDelegatorRewardsVolume(filter filters.Rewards) (total decimal.Decimal, err error)
+		GetValidatorRewards(filter filters.Rewards) (rewards []dmodels.ValidatorReward, err error)
 		CreateProposalDeposits(deposits []dmodels.ProposalDeposit) error
 		GetProposalDeposits(filter filters.ProposalDeposits) (deposits []dmodels.ProposalDeposit, err error)
 		CreateProposalVotes(votes []dmodels.ProposalVote) error
 		GetProposalVotes(filter filters.ProposalVotes) (votes []dmodels.ProposalVote, err error)
 		GetAggProposalVotes(filter filters.Agg, id []uint64) (items []smodels.AggItem, err error)
 		GetTotalVotesByAddress(address string) (total uint64, err error)
+		GetLastVotes(filter filters.LastVotes) (votes []smodels.LastVote, err error)
+		GetDelegatorOverrides(filter filters.DelegatorOverrides) (items []smodels.ProposalCount, err error)
 		CreateHistoricalStates(states []dmodels.HistoricalState) error
 		GetHistoricalStates(state filters.HistoricalState) (states []dmodels.HistoricalState, err error)
 		GetAggHistoricalStatesByField(filter filters.Agg, field string) (items []smodels.AggItem, err error)
 		GetActiveAccounts(filter filters.ActiveAccounts) (addresses []string, err error)
+		GetAccountTransactions(filter filters.AccountTransactions) (items []smodels.AccountTransaction, err error)
+		GetAccountTransactionsTotal(filter filters.AccountTransactions) (total uint64, err error)
 		CreateBalanceUpdates(updates []dmodels.BalanceUpdate) error
 		GetBalanceUpdate(filter filters.BalanceUpdates) (updates []dmodels.BalanceUpdate, err error)
+		GetAggBalanceUpdates(filter filters.BalanceUpdatesAgg) (items []smodels.BalanceAggItem, err error)
 		CreateJailers(jailers []dmodels.Jailer) error
 		GetJailersTotal() (total uint64, err error)
 		CreateStats(stats []dmodels.Stat) (err error)
@@ -78,16 +109,36 @@ type (
 		GetHistoryProposals(filter filters.HistoryProposals) (proposals []dmodels.HistoryProposal, err error)
 		GetAggValidators33Power(filter filters.Agg) (items []smodels.AggItem, err error)
 		GetAggWhaleAccounts(filter filters.Agg) (items []smodels.AggItem, err error)
+		GetAggStatsByTitle(filter filters.Agg, title string) (items []smodels.AggItem, err error)
 		GetProposedBlocksTotal(filter filters.BlocksProposed) (total uint64, err error)
-		GetVotingPower(filter filters.VotingPower) (volume decimal.Decimal, err error)
+		CreateValidatorPowers(powers []dmodels.ValidatorPower) error
+		CreateValidatorRevenues(revenues []dmodels.ValidatorRevenue) error
+		GetValidatorCommissionVolume(filter filters.ValidatorRevenues) (total decimal.Decimal, err error)
+		GetAggValidatorRevenues(filter filters.ValidatorRevenueAgg) (items []smodels.ValidatorRevenueAggItem, err error)
+		GetAggValidatorCommissionWithdrawals(filter filters.ValidatorRevenueAgg, currency string) (items []smodels.AggItem, err error)
+		GetValidatorPowers(filter filters.ValidatorPowers) (items []dmodels.ValidatorValue, err error)
+		GetValidatorPowerChanges(filter filters.ValidatorPowerChanges) (items []dmodels.ValidatorPower, err error)
 		GetAvgOperationsPerBlock(filter filters.Agg) (items []smodels.AggItem, err error)
 		CreateMissedBlocks(blocks []dmodels.MissedBlock) error
 		GetTopProposedBlocksValidators() (items []dmodels.ValidatorValue, err error)
 		GetMostJailedValidators() (items []dmodels.ValidatorValue, err error)
 		GetValidatorsDelegatorsTotal() (values []dmodels.ValidatorValue, err error)
 		GetMissedBlocksCount(filter filters.MissedBlocks) (total uint64, err error)
+		GetMissedBlocksHeights(filter filters.MissedBlocks) (heights []uint64, err error)
+		GetValidatorsMissedBlocks(filter filters.MissedBlocks) (items []dmodels.ValidatorValue, err error)
 		GetValidatorDelegators(filter filters.ValidatorDelegators) (items []dmodels.ValidatorDelegator, err error)
 		GetValidatorDelegatorsTotal(filter filters.ValidatorDelegators) (total uint64, err error)
+		CreateIBCTransfers(transfers []dmodels.IBCTransfer) error
+		DeleteBlocksAbove(height uint64) error
+		CreateValidatorEvents(events []dmodels.ValidatorEvent) error
+		GetValidatorEvents(filter filters.ValidatorEvents) (events []dmodels.ValidatorEvent, err error)
+		GetValidatorEventsTotal(filter filters.ValidatorEvents) (total uint64, err error)
+		CreateSlashes(slashes []dmodels.Slash) error
+		GetSlashes(filter filters.Slashes) (slashes []dmodels.Slash, err error)
+		GetSlashesTotal(filter filters.Slashes) (total uint64, err error)
+		GetIBCTransfers(filter filters.IBCTransfers) (transfers []dmodels.IBCTransfer, err error)
+		GetIBCTransfersTotal(filter filters.IBCTransfers) (total uint64, err error)
+		GetAggIBCChannelsVolume(filter filters.IBCChannelsAgg) (items []smodels.IBCChannelAggItem, err error)
 	}
 
 	Cache interface {
@@ -107,7 +158,7 @@ func NewDAO(cfg config.Config) (DAO, error) {
 	if err != nil {
 		return nil, fmt.Errorf("mysql.NewDB: %s", err.Error())
 	}
-	ch, err := clickhouse.NewDB(cfg.Clickhouse)
+	ch, err := clickhouse.NewDB(cfg.Clickhouse, cfg.Chain)
 	if err != nil {
 		return nil, fmt.Errorf("clickhouse.NewDB: %s", err.Error())
 	}
@@ -6,6 +6,7 @@ import (
 )
 
 type Accounts struct {
+	Addresses     []string
 	LtTotalAmount decimal.Decimal
 	GtTotalAmount decimal.Decimal
 }
@@ -14,3 +15,14 @@ type ActiveAccounts struct {
 	From time.Time
 	To   time.Time
 }
+
+type AccountTransactions struct {
+	Address string `schema:"-"`
+	Limit   uint64 `schema:"limit"`
+	Offset  uint64 `schema:"offset"`
+}
+
+type TopAccounts struct {
+	Limit  uint64 `schema:"limit"`
+	Offset uint64 `schema:"offset"`
+}
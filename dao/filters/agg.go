@@ -16,9 +16,10 @@ const (
 )
 
 type Agg struct {
-	By   string       `schema:"by"`
-	From dmodels.Time `schema:"from"`
-	To   dmodels.Time `schema:"to"`
+	By    string       `schema:"by"`
+	From  dmodels.Time `schema:"from"`
+	To    dmodels.Time `schema:"to"`
+	Denom string       `schema:"denom"`
 }
 
 var aggLimits = map[string]struct {
@@ -52,6 +53,9 @@ func (agg *Agg) Validate() error {
 		agg.From = dmodels.NewTime(time.Now().Add(-limit.defaultRange))
 		agg.To = dmodels.NewTime(time.Now())
 	} else {
+		if agg.To.IsZero() || agg.To.After(time.Now()) {
+			agg.To = dmodels.NewTime(time.Now())
+		}
 		d := agg.To.Sub(agg.From.Time)
 		if d > limit.maxRange {
 			return fmt.Errorf("over max limit range")
@@ -60,6 +64,14 @@ func (agg *Agg) Validate() error {
 	return nil
 }
 
+// Currency returns the requested denom or the given default one.
+func (agg *Agg) Currency(defaultCurrency string) string {
+	if agg.Denom == "" {
+		return defaultCurrency
+	}
+	return agg.Denom
+}
+
 func (agg *Agg) AggFunc() string {
 	switch agg.By {
 	case AggByHour:
@@ -75,6 +87,46 @@ func (agg *Agg) AggFunc() string {
 	}
 }
 
+// Periods returns the starts of the periods of the range in UTC, the same as AggFunc makes them.
+func (agg *Agg) Periods() (periods []time.Time) {
+	to := agg.To.Time
+	if to.IsZero() {
+		to = time.Now()
+	}
+	for t := agg.periodStart(agg.From.UTC()); !t.After(to); t = agg.NextPeriod(t) {
+		periods = append(periods, t)
+	}
+	return periods
+}
+
+func (agg *Agg) periodStart(t time.Time) time.Time {
+	y, m, d := t.Date()
+	switch agg.By {
+	case AggByHour:
+		return t.Truncate(time.Hour)
+	case AggByWeek:
+		return time.Date(y, m, d-int(t.Weekday()), 0, 0, 0, 0, time.UTC)
+	case AggByMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// NextPeriod returns the start of the period following the one started at t.
+func (agg *Agg) NextPeriod(t time.Time) time.Time {
+	switch agg.By {
+	case AggByHour:
+		return t.Add(time.Hour)
+	case AggByWeek:
+		return t.AddDate(0, 0, 7)
+	case AggByMonth:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
 func (agg *Agg) BuildQuery(aggValue string, timeColumn string, table string) squirrel.SelectBuilder {
 	q := squirrel.Select(
 		fmt.Sprintf("%s as value", aggValue),
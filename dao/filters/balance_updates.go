@@ -1,6 +1,13 @@
 package filters
 
 type BalanceUpdates struct {
-	Limit  uint64
-	Offset uint64
+	TimeRange
+	Address string
+	Limit   uint64
+	Offset  uint64
+}
+
+type BalanceUpdatesAgg struct {
+	Agg
+	Address string `schema:"-"`
 }
@@ -1,8 +1,9 @@
 package filters
 
 type Blocks struct {
-	Limit  uint64
-	Offset uint64
+	Heights []uint64 `schema:"-"`
+	Limit   uint64   `schema:"limit"`
+	Offset  uint64   `schema:"offset"`
 }
 
 type BlocksProposed struct {
@@ -1,5 +1,9 @@
 package filters
 
+type Delegations struct {
+	TxHash string
+}
+
 type Delegators struct {
 	TimeRange
 	Validators []string `schema:"validators"`
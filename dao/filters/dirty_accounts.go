@@ -0,0 +1,5 @@
+package filters
+
+type DirtyAccounts struct {
+	Limit uint64
+}
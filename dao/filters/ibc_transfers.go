@@ -0,0 +1,16 @@
+package filters
+
+type IBCTransfers struct {
+	ID        []string `schema:"-"`
+	Channel   string   `schema:"channel"`
+	Address   string   `schema:"address"`
+	Status    string   `schema:"status"`
+	Direction string   `schema:"direction"`
+	Limit     uint64   `schema:"limit"`
+	Offset    uint64   `schema:"offset"`
+}
+
+type IBCChannelsAgg struct {
+	Agg
+	Channels []string `schema:"channels"`
+}
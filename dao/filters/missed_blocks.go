@@ -1,5 +1,28 @@
 package filters
 
+import "fmt"
+
+const (
+	UptimeMinBlocks = 100
+	UptimeMaxBlocks = 10000
+)
+
 type MissedBlocks struct {
 	Validators []string
+	HeightFrom uint64
+	HeightTo   uint64
+}
+
+type Uptime struct {
+	Blocks uint64 `schema:"blocks"`
+}
+
+func (filter *Uptime) Validate() error {
+	if filter.Blocks == 0 {
+		filter.Blocks = UptimeMinBlocks
+	}
+	if filter.Blocks < UptimeMinBlocks || filter.Blocks > UptimeMaxBlocks {
+		return fmt.Errorf("`blocks` should be from %d to %d", UptimeMinBlocks, UptimeMaxBlocks)
+	}
+	return nil
 }
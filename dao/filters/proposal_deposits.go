@@ -2,4 +2,5 @@ package filters
 
 type ProposalDeposits struct {
 	ProposalID []uint64 `schema:"proposal_id"`
+	TxHash     string   `schema:"-"`
 }
@@ -1,8 +1,47 @@
 package filters
 
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	ParticipationDefaultProposals = 10
+	ParticipationMaxProposals     = 100
+)
+
 type ProposalVotes struct {
 	ProposalID uint64   `schema:"proposal_id"`
 	Voters     []string `schema:"voters"`
+	TxHash     string   `schema:"-"`
 	Limit      uint64   `schema:"limit"`
 	Offset     uint64   `schema:"offset"`
 }
+
+type LastVotes struct {
+	ProposalIDs []uint64
+	Voters      []string
+}
+
+// DelegatorOverrides selects the votes of the delegators of the validator, the own account of the validator is skipped.
+// VotingEndTimes holds the voting end time of every proposal, the delegators are taken as of that time.
+type DelegatorOverrides struct {
+	VotingEndTimes   map[uint64]time.Time
+	Validator        string
+	ValidatorAccount string
+}
+
+// Participation sets the number of the last proposals ended in voting the participation is scored over.
+type Participation struct {
+	Proposals uint64 `schema:"proposals"`
+}
+
+func (filter *Participation) Validate() error {
+	if filter.Proposals == 0 {
+		filter.Proposals = ParticipationDefaultProposals
+	}
+	if filter.Proposals > ParticipationMaxProposals {
+		return fmt.Errorf("`proposals` should be up to %d", ParticipationMaxProposals)
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+package filters
+
+type Rewards struct {
+	TimeRange
+	TxHash    string
+	Delegator string
+	Currency  string
+}
@@ -0,0 +1,8 @@
+package filters
+
+type Slashes struct {
+	Validators []string `schema:"-"`
+	Reason     string   `schema:"reason"`
+	Limit      uint64   `schema:"limit"`
+	Offset     uint64   `schema:"offset"`
+}
@@ -0,0 +1,29 @@
+package filters
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+const CalculatorMaxDays = 3650
+
+type StakingCalculator struct {
+	Amount    decimal.Decimal `schema:"amount"`
+	Validator string          `schema:"validator"`
+	Days      uint64          `schema:"days"`
+	Compound  bool            `schema:"compound"`
+}
+
+func (filter *StakingCalculator) Validate() error {
+	if !filter.Amount.IsPositive() {
+		return fmt.Errorf("`amount` should be positive")
+	}
+	if filter.Validator == "" {
+		return fmt.Errorf("`validator` is required")
+	}
+	if filter.Days == 0 || filter.Days > CalculatorMaxDays {
+		return fmt.Errorf("`days` should be from 1 to %d", CalculatorMaxDays)
+	}
+	return nil
+}
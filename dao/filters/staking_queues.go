@@ -0,0 +1,26 @@
+package filters
+
+import (
+	"time"
+
+	"github.com/kwanifi/numiscan-api/dmodels"
+)
+
+const redelegationFlowsDefaultRange = time.Hour * 24 * 30
+
+type UnbondingSchedule struct {
+	Validator string `schema:"validator"`
+	Delegator string `schema:"delegator"`
+}
+
+type RedelegationFlows struct {
+	TimeRange
+	Validator string `schema:"validator"`
+}
+
+func (filter *RedelegationFlows) Validate() error {
+	if filter.From.IsZero() {
+		filter.From = dmodels.NewTime(time.Now().Add(-redelegationFlowsDefaultRange))
+	}
+	return nil
+}
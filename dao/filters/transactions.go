@@ -0,0 +1,28 @@
+package filters
+
+import (
+	"fmt"
+
+	"github.com/kwanifi/numiscan-api/dmodels"
+)
+
+type Transactions struct {
+	Hash        []string `schema:"-"`
+	Height      uint64   `schema:"height"`
+	Status      string   `schema:"status"`
+	MessageType string   `schema:"message_type"`
+	Limit       uint64   `schema:"limit"`
+	Offset      uint64   `schema:"offset"`
+}
+
+func (filter *Transactions) Validate() error {
+	if filter.Status != "" && filter.Status != dmodels.TransactionStatusSuccess && filter.Status != dmodels.TransactionStatusFailed {
+		return fmt.Errorf("unknown `status` param")
+	}
+	return nil
+}
+
+type MessageTypesAgg struct {
+	Agg
+	Limit uint64 `schema:"limit"`
+}
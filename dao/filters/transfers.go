@@ -0,0 +1,5 @@
+package filters
+
+type Transfers struct {
+	TxHash string
+}
@@ -0,0 +1,8 @@
+package filters
+
+type ValidatorEvents struct {
+	Validator    string `schema:"-"`
+	BeforeHeight uint64 `schema:"-"`
+	Limit        uint64 `schema:"limit"`
+	Offset       uint64 `schema:"offset"`
+}
@@ -0,0 +1,17 @@
+package filters
+
+import "github.com/kwanifi/numiscan-api/dmodels"
+
+// ValidatorPowers selects the powers known at the height or at the time, the latest ones when both are empty.
+type ValidatorPowers struct {
+	Validators []string     `schema:"-"`
+	Height     uint64       `schema:"height"`
+	At         dmodels.Time `schema:"-"`
+}
+
+// ValidatorPowerChanges selects the power changes in the heights range, all validators when Validators is empty.
+type ValidatorPowerChanges struct {
+	Validators []string
+	HeightFrom uint64
+	HeightTo   uint64
+}
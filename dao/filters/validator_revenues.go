@@ -0,0 +1,11 @@
+package filters
+
+type ValidatorRevenues struct {
+	TimeRange
+	Validator string
+}
+
+type ValidatorRevenueAgg struct {
+	Agg
+	Validator string `schema:"-"`
+}
@@ -1,7 +0,0 @@
-package filters
-
-type VotingPower struct {
-	TimeRange
-	Delegators []string
-	Validators []string
-}
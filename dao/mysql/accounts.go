@@ -2,10 +2,12 @@ package mysql
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/kwanifi/numiscan-api/dao/filters"
 	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/shopspring/decimal"
 )
 
 func (m DB) CreateAccounts(accounts []dmodels.Account) error {
@@ -51,6 +53,9 @@ func (m DB) UpdateAccount(account dmodels.Account) error {
 
 func (m DB) GetAccounts(filter filters.Accounts) (accounts []dmodels.Account, err error) {
 	q := squirrel.Select("*").From(dmodels.AccountsTable)
+	if len(filter.Addresses) != 0 {
+		q = q.Where(squirrel.Eq{"acc_address": filter.Addresses})
+	}
 	if !filter.GtTotalAmount.IsZero() {
 		q = q.Where(squirrel.Gt{"acc_balance + acc_stake": filter.GtTotalAmount})
 	}
@@ -78,3 +83,64 @@ func (m DB) GetAccount(address string) (account dmodels.Account, err error) {
 	err = m.first(&account, q)
 	return account, err
 }
+
+// GetTopAccounts returns the accounts ordered by the sum of balance, stake and unbonding.
+func (m DB) GetTopAccounts(filter filters.TopAccounts) (accounts []dmodels.Account, err error) {
+	q := squirrel.Select("*").From(dmodels.AccountsTable).
+		OrderBy("acc_balance + acc_stake + acc_unbonding desc", "acc_address")
+	if filter.Limit != 0 {
+		q = q.Limit(filter.Limit)
+	}
+	if filter.Offset != 0 {
+		q = q.Offset(filter.Offset)
+	}
+	err = m.find(&accounts, q)
+	return accounts, err
+}
+
+// nonEmptyHoldings selects the non-empty total holdings of the accounts.
+func nonEmptyHoldings() squirrel.SelectBuilder {
+	return squirrel.Select("acc_balance + acc_stake + acc_unbonding AS total").
+		From(dmodels.AccountsTable).
+		Where("acc_balance + acc_stake + acc_unbonding > 0")
+}
+
+// GetAccountsDistribution groups the non-empty holdings by the ranges starting from the bounds,
+// the holdings below the second bound fall into the first range.
+func (m DB) GetAccountsDistribution(bounds []decimal.Decimal) (ranges []dmodels.AccountsRange, err error) {
+	index := squirrel.Case().Else("0")
+	for i := len(bounds) - 1; i > 0; i-- {
+		index = index.When(squirrel.Expr("total >= ?", bounds[i]), strconv.Itoa(i))
+	}
+	indexSQL, indexArgs, err := index.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	q := squirrel.Select().
+		Column(squirrel.Expr(indexSQL+" AS range_index", indexArgs...)).
+		Columns("count(*) AS accounts", "sum(total) AS amount").
+		FromSelect(nonEmptyHoldings(), "h").
+		GroupBy("range_index")
+	err = m.find(&ranges, q)
+	return ranges, err
+}
+
+// GetAccountsHoldings returns the sums the Gini coefficient of the non-empty holdings is computed from.
+func (m DB) GetAccountsHoldings() (holdings dmodels.AccountsHoldings, err error) {
+	ranked := nonEmptyHoldings().Column("row_number() OVER (ORDER BY acc_balance + acc_stake + acc_unbonding) AS position")
+	q := squirrel.Select(
+		"count(*) AS accounts",
+		"coalesce(sum(total), 0) AS amount",
+		"coalesce(sum(total * position), 0) AS weighted",
+	).FromSelect(ranked, "h")
+	err = m.first(&holdings, q)
+	return holdings, err
+}
+
+// GetTopAccountsAmount returns the sum of the limit largest holdings.
+func (m DB) GetTopAccountsAmount(limit uint64) (amount decimal.Decimal, err error) {
+	top := nonEmptyHoldings().OrderBy("total DESC").Limit(limit)
+	q := squirrel.Select("coalesce(sum(total), 0) AS amount").FromSelect(top, "h")
+	err = m.first(&amount, q)
+	return amount, err
+}
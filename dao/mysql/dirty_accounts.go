@@ -0,0 +1,54 @@
+package mysql
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/dmodels"
+)
+
+// CreateDirtyAccounts marks the accounts as dirty, the time of already marked ones is moved forward
+// and their version is increased.
+func (m DB) CreateDirtyAccounts(accounts []dmodels.DirtyAccount) error {
+	if len(accounts) == 0 {
+		return nil
+	}
+	q := squirrel.Insert(dmodels.DirtyAccountsTable).Columns("dac_address", "dac_updated_at")
+	for _, account := range accounts {
+		if account.Address == "" {
+			return fmt.Errorf("field Address is empty")
+		}
+		if account.UpdatedAt.IsZero() {
+			return fmt.Errorf("field UpdatedAt is empty")
+		}
+		q = q.Values(account.Address, account.UpdatedAt)
+	}
+	q = q.Suffix("ON DUPLICATE KEY UPDATE dac_updated_at = VALUES(dac_updated_at), dac_version = dac_version + 1")
+	_, err := m.insert(q)
+	return err
+}
+
+func (m DB) GetDirtyAccounts(filter filters.DirtyAccounts) (accounts []dmodels.DirtyAccount, err error) {
+	q := squirrel.Select("*").From(dmodels.DirtyAccountsTable).OrderBy("dac_updated_at")
+	if filter.Limit != 0 {
+		q = q.Limit(filter.Limit)
+	}
+	err = m.find(&accounts, q)
+	return accounts, err
+}
+
+// DeleteDirtyAccounts removes the marks of the taken versions, the accounts marked again after they were taken stay dirty.
+func (m DB) DeleteDirtyAccounts(accounts []dmodels.DirtyAccount) error {
+	if len(accounts) == 0 {
+		return nil
+	}
+	var or squirrel.Or
+	for _, account := range accounts {
+		or = append(or, squirrel.And{
+			squirrel.Eq{"dac_address": account.Address},
+			squirrel.Eq{"dac_version": account.Version},
+		})
+	}
+	return m.delete(squirrel.Delete(dmodels.DirtyAccountsTable).Where(or))
+}
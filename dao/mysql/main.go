@@ -129,6 +129,18 @@ func (m DB) update(sb squirrel.UpdateBuilder) (err error) {
 	return nil
 }
 
+func (m DB) delete(sb squirrel.DeleteBuilder) (err error) {
+	sql, args, err := sb.ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = m.db.Exec(sql, args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (m DB) migrate() error {
 	ex, err := os.Executable()
 	if err != nil {
@@ -0,0 +1,16 @@
+-- +migrate Up
+create table dirty_accounts
+(
+    dac_address    varchar(255)                        not null
+        primary key,
+    dac_updated_at timestamp default CURRENT_TIMESTAMP not null,
+    dac_version    bigint unsigned default 0           not null
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci;
+
+create index dirty_accounts_dac_updated_at_index
+    on dirty_accounts (dac_updated_at);
+
+-- +migrate Down
+drop table dirty_accounts;
@@ -21,6 +21,16 @@ func (m DB) GetParser(title string) (parser dmodels.Parser, err error) {
 	return parser, err
 }
 
+func (m DB) CreateParser(parser dmodels.Parser) error {
+	q := squirrel.Insert(dmodels.ParsersTable).
+		SetMap(map[string]interface{}{
+			"par_title":  parser.Title,
+			"par_height": parser.Height,
+		})
+	_, err := m.insert(q)
+	return err
+}
+
 func (m DB) UpdateParser(parser dmodels.Parser) error {
 	q := squirrel.Update(dmodels.ParsersTable).
 		Where(squirrel.Eq{"par_id": parser.ID}).
@@ -74,3 +74,9 @@ func (m DB) UpdateValidators(validator dmodels.Validator) error {
 		})
 	return m.update(q)
 }
+
+func (m DB) GetValidator(consAddress string) (validator dmodels.Validator, err error) {
+	q := squirrel.Select("*").From(dmodels.ValidatorsTable).Where(squirrel.Eq{"val_cons_address": consAddress})
+	err = m.first(&validator, q)
+	return validator, err
+}
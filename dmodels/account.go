@@ -14,3 +14,18 @@ type Account struct {
 	Unbonding decimal.Decimal `db:"acc_unbonding"`
 	CreatedAt time.Time       `db:"acc_created_at"`
 }
+
+// AccountsRange is the number and the sum of the non-empty holdings in the range of the distribution.
+type AccountsRange struct {
+	Index    int             `db:"range_index"`
+	Accounts uint64          `db:"accounts"`
+	Amount   decimal.Decimal `db:"amount"`
+}
+
+// AccountsHoldings are the sums the Gini coefficient of the non-empty holdings is computed from.
+type AccountsHoldings struct {
+	Accounts uint64          `db:"accounts"`
+	Amount   decimal.Decimal `db:"amount"`
+	// Weighted is Σ(i * x_i) over the holdings sorted ascending, i starting from 1.
+	Weighted decimal.Decimal `db:"weighted"`
+}
@@ -13,5 +13,6 @@ type DelegatorReward struct {
 	Delegator string          `db:"der_delegator"`
 	Validator string          `db:"der_validator"`
 	Amount    decimal.Decimal `db:"der_amount"`
+	Currency  string          `db:"der_currency"`
 	CreatedAt time.Time       `db:"der_created_at"`
 }
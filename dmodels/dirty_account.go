@@ -0,0 +1,13 @@
+package dmodels
+
+import "time"
+
+const DirtyAccountsTable = "dirty_accounts"
+
+// DirtyAccount is an account touched by the parsed operations, its balances have to be refreshed from the node.
+// Version is increased every time the account is marked again.
+type DirtyAccount struct {
+	Address   string    `db:"dac_address"`
+	UpdatedAt time.Time `db:"dac_updated_at"`
+	Version   uint64    `db:"dac_version"`
+}
@@ -0,0 +1,35 @@
+package dmodels
+
+import "github.com/shopspring/decimal"
+
+const IBCTransfersTable = "ibc_transfers"
+
+const (
+	IBCTransferStatusPending      = "pending"
+	IBCTransferStatusReceived     = "received"
+	IBCTransferStatusAcknowledged = "acknowledged"
+	IBCTransferStatusFailed       = "failed"
+	IBCTransferStatusTimeout      = "timeout"
+
+	IBCTransferDirectionIn  = "in"
+	IBCTransferDirectionOut = "out"
+)
+
+type IBCTransfer struct {
+	ID                 string          `db:"ibt_id" json:"id"`
+	TxHash             string          `db:"ibt_tx_hash" json:"tx_hash"`
+	AckTxHash          string          `db:"ibt_ack_tx_hash" json:"ack_tx_hash"`
+	Direction          string          `db:"ibt_direction" json:"direction"`
+	Status             string          `db:"ibt_status" json:"status"`
+	SourcePort         string          `db:"ibt_source_port" json:"source_port"`
+	SourceChannel      string          `db:"ibt_source_channel" json:"source_channel"`
+	DestinationPort    string          `db:"ibt_destination_port" json:"destination_port"`
+	DestinationChannel string          `db:"ibt_destination_channel" json:"destination_channel"`
+	Sequence           uint64          `db:"ibt_sequence" json:"sequence"`
+	Sender             string          `db:"ibt_sender" json:"sender"`
+	Receiver           string          `db:"ibt_receiver" json:"receiver"`
+	Denom              string          `db:"ibt_denom" json:"denom"`
+	Amount             decimal.Decimal `db:"ibt_amount" json:"amount"`
+	CreatedAt          Time            `db:"ibt_created_at" json:"created_at"`
+	UpdatedAt          Time            `db:"ibt_updated_at" json:"updated_at"`
+}
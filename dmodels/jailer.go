@@ -7,5 +7,6 @@ const JailersTable = "jailers"
 type Jailer struct {
 	ID        string    `db:"jlr_id"`
 	Address   string    `db:"jlr_address"`
+	TxHash    string    `db:"jlr_tx_hash"`
 	CreatedAt time.Time `db:"jlr_created_at"`
 }
@@ -7,6 +7,14 @@ import (
 
 const ProposalsTable = "proposals"
 
+const (
+	ProposalStatusDepositPeriod = "DepositPeriod"
+	ProposalStatusVotingPeriod  = "VotingPeriod"
+	ProposalStatusPassed        = "Passed"
+	ProposalStatusRejected      = "Rejected"
+	ProposalStatusFailed        = "Failed"
+)
+
 type Proposal struct {
 	ID                uint64          `db:"pro_id" json:"id"`
 	TxHash            string          `db:"pro_tx_hash" json:"tx_hash"`
@@ -10,6 +10,8 @@ type ProposalDeposit struct {
 	ID         string          `db:"prd_id" json:"-"`
 	ProposalID uint64          `db:"prd_proposal_id" json:"proposal_id"`
 	Depositor  string          `db:"prd_depositor" json:"depositor"`
+	TxHash     string          `db:"prd_tx_hash" json:"tx_hash"`
 	Amount     decimal.Decimal `db:"prd_amount" json:"amount"`
+	Currency   string          `db:"prd_currency" json:"currency"`
 	CreatedAt  Time            `db:"prd_created_at" json:"created_at"`
 }
@@ -2,6 +2,9 @@ package dmodels
 
 const ProposalVotesTable = "proposal_votes"
 
+// ProposalVoteDidNotVote stands for the option of a voter without a vote on the proposal.
+const ProposalVoteDidNotVote = "DidNotVote"
+
 type ProposalVote struct {
 	ID         string `db:"prv_id" json:"-"`
 	ProposalID uint64 `db:"prv_proposal_id" json:"proposal_id"`
@@ -0,0 +1,22 @@
+package dmodels
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const RedelegationsTable = "redelegations"
+
+// Redelegation is the stake moved between validators, it can not be redelegated again until the completion time.
+type Redelegation struct {
+	ID             string          `db:"red_id"`
+	Height         uint64          `db:"red_height"`
+	TxHash         string          `db:"red_tx_hash"`
+	Delegator      string          `db:"red_delegator"`
+	ValidatorSrc   string          `db:"red_validator_src"`
+	ValidatorDst   string          `db:"red_validator_dst"`
+	Amount         decimal.Decimal `db:"red_amount"`
+	CompletionTime time.Time       `db:"red_completion_time"`
+	CreatedAt      time.Time       `db:"red_created_at"`
+}
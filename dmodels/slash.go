@@ -0,0 +1,26 @@
+package dmodels
+
+import "github.com/shopspring/decimal"
+
+const SlashesTable = "slashes"
+
+const (
+	SlashReasonDoubleSign       = "double_sign"
+	SlashReasonMissingSignature = "missing_signature"
+)
+
+// Slash is a slashing of the validator found in the begin block events. Validator is the hex consensus address,
+// Burned is an estimate: the power in base units (chain.power_reduction) times the slash fraction. The v0.42 SDK
+// emits no burn events, so the slashed unbonding and redelegation entries are not included.
+type Slash struct {
+	ID               string          `db:"sls_id" json:"-"`
+	Height           uint64          `db:"sls_height" json:"height"`
+	InfractionHeight uint64          `db:"sls_infraction_height" json:"infraction_height"`
+	Validator        string          `db:"sls_validator" json:"validator"`
+	Reason           string          `db:"sls_reason" json:"reason"`
+	Power            uint64          `db:"sls_power" json:"power"`
+	Burned           decimal.Decimal `db:"sls_burned" json:"burned"`
+	MissedBlocks     uint64          `db:"sls_missed_blocks" json:"missed_blocks"`
+	Jailed           bool            `db:"sls_jailed" json:"jailed"`
+	CreatedAt        Time            `db:"sls_created_at" json:"created_at"`
+}
@@ -23,6 +23,16 @@ const (
 	StatsTotalSmallAccounts    = "total_small_accounts"
 	StatsTotalJailers          = "total_jailers"
 	StatsValidatorsWith33Power = "validators_with_33_power"
+	StatsStakingAPR            = "staking_apr"
+	StatsStakingRealizedAPR    = "staking_realized_apr"
+	StatsNakamoto33            = "nakamoto_33"
+	StatsNakamoto66            = "nakamoto_66"
+	StatsValidatorsGini        = "validators_gini"
+	StatsValidatorsHHI         = "validators_hhi"
+	StatsBottomHalfShare       = "validators_bottom_half_share"
+
+	// StatsAccountsDistribution is followed by the index of the range
+	StatsAccountsDistribution = "accounts_distribution_"
 )
 
 type Stat struct {
@@ -7,13 +7,24 @@ import (
 
 const TransactionsTable = "transactions"
 
+const (
+	TransactionStatusSuccess = "success"
+	TransactionStatusFailed  = "failed"
+)
+
 type Transaction struct {
-	Hash      string          `db:"trn_hash"`
-	Status    bool            `db:"trn_status"`
-	Height    uint64          `db:"trn_height"`
-	Messages  uint64          `db:"trn_messages"`
-	Fee       decimal.Decimal `db:"trn_fee"`
-	GasUsed   uint64          `db:"trn_gas_used"`
-	GasWanted uint64          `db:"trn_gas_wanted"`
-	CreatedAt time.Time       `db:"trn_created_at"`
+	Hash         string          `db:"trn_hash"`
+	Status       bool            `db:"trn_status"`
+	Height       uint64          `db:"trn_height"`
+	Messages     uint64          `db:"trn_messages"`
+	Fee          decimal.Decimal `db:"trn_fee"`
+	GasUsed      uint64          `db:"trn_gas_used"`
+	GasWanted    uint64          `db:"trn_gas_wanted"`
+	Memo         string          `db:"trn_memo"`
+	FeePayer     string          `db:"trn_fee_payer"`
+	FeeGranter   string          `db:"trn_fee_granter"`
+	Signers      []string        `db:"trn_signers"`
+	MessageTypes []string        `db:"trn_message_types"`
+	RawLog       string          `db:"trn_raw_log"`
+	CreatedAt    time.Time       `db:"trn_created_at"`
 }
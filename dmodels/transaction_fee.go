@@ -0,0 +1,16 @@
+package dmodels
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+const TransactionFeesTable = "transaction_fees"
+
+type TransactionFee struct {
+	ID        string          `db:"txf_id"`
+	TxHash    string          `db:"txf_tx_hash"`
+	Currency  string          `db:"txf_currency"`
+	Amount    decimal.Decimal `db:"txf_amount"`
+	CreatedAt time.Time       `db:"txf_created_at"`
+}
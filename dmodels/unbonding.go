@@ -0,0 +1,21 @@
+package dmodels
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const UnbondingsTable = "unbondings"
+
+// Unbonding is the undelegated stake, it stays locked until the completion time.
+type Unbonding struct {
+	ID             string          `db:"unb_id"`
+	Height         uint64          `db:"unb_height"`
+	TxHash         string          `db:"unb_tx_hash"`
+	Delegator      string          `db:"unb_delegator"`
+	Validator      string          `db:"unb_validator"`
+	Amount         decimal.Decimal `db:"unb_amount"`
+	CompletionTime time.Time       `db:"unb_completion_time"`
+	CreatedAt      time.Time       `db:"unb_created_at"`
+}
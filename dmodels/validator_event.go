@@ -0,0 +1,32 @@
+package dmodels
+
+import "github.com/shopspring/decimal"
+
+const ValidatorEventsTable = "validator_events"
+
+const (
+	ValidatorEventCreate = "create"
+	ValidatorEventEdit   = "edit"
+)
+
+// ValidatorEvent keeps the state of the validator after the create or edit message.
+type ValidatorEvent struct {
+	ID                string          `db:"vle_id" json:"-"`
+	TxHash            string          `db:"vle_tx_hash" json:"tx_hash"`
+	Height            uint64          `db:"vle_height" json:"height"`
+	Type              string          `db:"vle_type" json:"type"`
+	OperatorAddress   string          `db:"vle_operator_address" json:"operator_address"`
+	Address           string          `db:"vle_address" json:"address"`
+	ConsAddress       string          `db:"vle_cons_address" json:"cons_address"`
+	ConsPubKey        string          `db:"vle_cons_pub_key" json:"cons_pub_key"`
+	Moniker           string          `db:"vle_moniker" json:"moniker"`
+	Identity          string          `db:"vle_identity" json:"identity"`
+	Website           string          `db:"vle_website" json:"website"`
+	Details           string          `db:"vle_details" json:"details"`
+	CommissionRate    decimal.Decimal `db:"vle_commission_rate" json:"commission_rate"`
+	MaxRate           decimal.Decimal `db:"vle_max_rate" json:"max_rate"`
+	MaxChangeRate     decimal.Decimal `db:"vle_max_change_rate" json:"max_change_rate"`
+	MinSelfDelegation decimal.Decimal `db:"vle_min_self_delegation" json:"min_self_delegation"`
+	SelfBond          decimal.Decimal `db:"vle_self_bond" json:"self_bond"`
+	CreatedAt         Time            `db:"vle_created_at" json:"created_at"`
+}
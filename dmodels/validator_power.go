@@ -0,0 +1,15 @@
+package dmodels
+
+import "time"
+
+const ValidatorPowersTable = "validator_powers"
+
+// ValidatorPower is the tendermint voting power of the validator from the height,
+// a row is written only when the power changes, zero power means the validator left the set.
+type ValidatorPower struct {
+	ID        string    `db:"vlp_id"`
+	Height    uint64    `db:"vlp_height"`
+	Validator string    `db:"vlp_validator"`
+	Power     uint64    `db:"vlp_power"`
+	CreatedAt time.Time `db:"vlp_created_at"`
+}
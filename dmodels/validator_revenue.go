@@ -0,0 +1,21 @@
+package dmodels
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const ValidatorRevenuesTable = "validator_revenues"
+
+// ValidatorRevenue is the distribution of the block to the validator in the staking currency:
+// the commission, the rewards of the delegators and the proposer reward which is already included into both.
+type ValidatorRevenue struct {
+	ID             string          `db:"vrv_id"`
+	Height         uint64          `db:"vrv_height"`
+	Validator      string          `db:"vrv_validator"`
+	Commission     decimal.Decimal `db:"vrv_commission"`
+	Rewards        decimal.Decimal `db:"vrv_rewards"`
+	ProposerReward decimal.Decimal `db:"vrv_proposer_reward"`
+	CreatedAt      time.Time       `db:"vrv_created_at"`
+}
@@ -12,5 +12,6 @@ type ValidatorReward struct {
 	TxHash    string          `db:"var_tx_hash"`
 	Address   string          `db:"var_address"`
 	Amount    decimal.Decimal `db:"var_amount"`
+	Currency  string          `db:"var_currency"`
 	CreatedAt time.Time       `db:"var_created_at"`
 }
@@ -11,6 +11,7 @@ require (
 	github.com/golang-migrate/migrate/v4 v4.11.0
 	github.com/gorilla/mux v1.8.0
 	github.com/gorilla/schema v1.1.0
+	github.com/gorilla/websocket v1.4.2
 	github.com/jmoiron/sqlx v1.2.0
 	github.com/mailru/go-clickhouse v1.3.0
 	github.com/patrickmn/go-cache v2.1.0+incompatible
@@ -20,5 +21,5 @@ require (
 	github.com/tendermint/tendermint v0.34.8
 	github.com/urfave/negroni v1.0.0
 	go.uber.org/zap v1.13.0
-	google.golang.org/grpc v1.36.0 // indirect
+	google.golang.org/grpc v1.36.0
 )
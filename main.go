@@ -1,8 +1,10 @@
 package main
 
 import (
+	"flag"
 	"os"
 	"os/signal"
+	"strings"
 	"time"
 
 	"github.com/kwanifi/numiscan-api/api"
@@ -32,7 +34,15 @@ func main() {
 		log.Fatal("services.NewServices: %s", err.Error())
 	}
 
-	prs := hub3.NewParser(cfg, d)
+	prs, err := hub3.NewParser(cfg, d)
+	if err != nil {
+		log.Fatal("hub3.NewParser: %s", err.Error())
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "reindex" {
+		reindex(prs, os.Args[2:])
+		return
+	}
 
 	apiServer := api.NewAPI(cfg, s, d)
 
@@ -41,7 +51,8 @@ func main() {
 	sch.AddProcessWithInterval(s.UpdateValidatorsMap, time.Minute*10)
 	sch.AddProcessWithInterval(s.UpdateProposals, time.Minute*15)
 	sch.AddProcessWithInterval(s.UpdateValidators, time.Minute*15)
-	sch.EveryDayAt(s.MakeUpdateBalances, 1, 0)
+	sch.AddProcessWithInterval(s.RefreshDirtyAccounts, time.Second*30)
+	sch.EveryWeekAt(s.MakeUpdateBalances, time.Sunday, 1, 0)
 	sch.EveryDayAt(s.MakeStats, 2, 0)
 
 	go s.KeepHistoricalState()
@@ -49,7 +60,7 @@ func main() {
 	g := modules.NewGroup(apiServer, sch, prs)
 	g.Run()
 
-	interrupt := make(chan os.Signal)
+	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt, os.Kill)
 
 	<-interrupt
@@ -57,3 +68,24 @@ func main() {
 
 	os.Exit(0)
 }
+
+// reindex runs `numiscan-api reindex --from H1 --to H2 [--tables blocks,transfers]`.
+func reindex(prs *hub3.Parser, args []string) {
+	fs := flag.NewFlagSet("reindex", flag.ExitOnError)
+	from := fs.Uint64("from", 0, "first height to reindex")
+	to := fs.Uint64("to", 0, "last height to reindex")
+	tables := fs.String("tables", "", "comma separated tables to write, all by default")
+	_ = fs.Parse(args)
+
+	var list []string
+	for _, table := range strings.Split(*tables, ",") {
+		if table = strings.TrimSpace(table); table != "" {
+			list = append(list, table)
+		}
+	}
+	err := prs.Reindex(*from, *to, list)
+	if err != nil {
+		log.Fatal("reindex: %s", err.Error())
+	}
+	log.Info("reindex: heights %d - %d done", *from, *to)
+}
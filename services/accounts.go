@@ -1,15 +1,25 @@
 package services
 
 import (
-	"context"
+	"crypto/sha1"
+	"encoding/hex"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/kwanifi/numiscan-api/dao/derrors"
 	"github.com/kwanifi/numiscan-api/dao/filters"
 	"github.com/kwanifi/numiscan-api/dmodels"
 	"github.com/kwanifi/numiscan-api/log"
+	"github.com/kwanifi/numiscan-api/smodels"
 )
 
+const (
+	dirtyAccountsBatch   = 100
+	accountUpdateRetries = 3
+)
+
+// MakeUpdateBalances refreshes all known accounts, it reconciles the balances missed by RefreshDirtyAccounts.
 func (s *ServiceFacade) MakeUpdateBalances() {
 	tn := time.Now()
 	accounts, err := s.dao.GetAccounts(filters.Accounts{})
@@ -17,35 +27,93 @@ func (s *ServiceFacade) MakeUpdateBalances() {
 		log.Error("MakeUpdateBalances: dao.GetAccounts: %s", err.Error())
 		return
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	fetchers := 5
+	failed := s.refreshAccounts(accounts)
+	log.Info("MakeUpdateBalances finished, accounts: %d, failed: %d, duration: %s", len(accounts), len(failed), time.Now().Sub(tn))
+}
+
+// RefreshDirtyAccounts refreshes the accounts marked by the parser until none is left.
+// The failed accounts stay dirty and are taken on the next run.
+func (s *ServiceFacade) RefreshDirtyAccounts() {
+	for {
+		dirtyAccounts, err := s.dao.GetDirtyAccounts(filters.DirtyAccounts{Limit: dirtyAccountsBatch})
+		if err != nil {
+			log.Error("RefreshDirtyAccounts: dao.GetDirtyAccounts: %s", err.Error())
+			return
+		}
+		if len(dirtyAccounts) == 0 {
+			return
+		}
+		addresses := make([]string, len(dirtyAccounts))
+		for i, account := range dirtyAccounts {
+			addresses[i] = account.Address
+		}
+		accounts, err := s.dao.GetAccounts(filters.Accounts{Addresses: addresses})
+		if err != nil {
+			log.Error("RefreshDirtyAccounts: dao.GetAccounts: %s", err.Error())
+			return
+		}
+		failed := s.refreshAccounts(accounts)
+		refreshed := make([]dmodels.DirtyAccount, 0, len(dirtyAccounts))
+		for _, account := range dirtyAccounts {
+			if _, ok := failed[account.Address]; !ok {
+				refreshed = append(refreshed, account)
+			}
+		}
+		err = s.dao.DeleteDirtyAccounts(refreshed)
+		if err != nil {
+			log.Error("RefreshDirtyAccounts: dao.DeleteDirtyAccounts: %s", err.Error())
+			return
+		}
+		if len(failed) != 0 {
+			return
+		}
+	}
+}
+
+// refreshAccounts updates the accounts with the configured number of fetchers and rate limit,
+// it returns the addresses which are failed to update.
+func (s *ServiceFacade) refreshAccounts(accounts []dmodels.Account) map[string]struct{} {
+	var limiter <-chan time.Time
+	if s.cfg.Accounts.RateLimit != 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(s.cfg.Accounts.RateLimit))
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+	failed := make(map[string]struct{})
+	mu := &sync.Mutex{}
+	wg := &sync.WaitGroup{}
 	accountsCh := make(chan dmodels.Account)
-	for i := 0; i < fetchers; i++ {
+	for i := uint64(0); i < s.cfg.Accounts.Fetchers; i++ {
+		wg.Add(1)
 		go func() {
-			for {
-				select {
-				case acc := <-accountsCh:
-					for {
-						err := s.updateAccount(acc)
-						if err != nil {
-							log.Warn("MakeSmartUpdateBalances: updateAccount: %s", err.Error())
-							time.After(time.Second * 2)
-							continue
-						}
+			defer wg.Done()
+			for acc := range accountsCh {
+				for attempt := 1; ; attempt++ {
+					err := s.updateAccount(acc)
+					if err == nil {
 						break
 					}
-				case <-ctx.Done():
-					return
+					log.Warn("refreshAccounts: updateAccount (%s): %s", acc.Address, err.Error())
+					if attempt == accountUpdateRetries {
+						mu.Lock()
+						failed[acc.Address] = struct{}{}
+						mu.Unlock()
+						break
+					}
+					<-time.After(time.Second * 2)
 				}
 			}
 		}()
 	}
 	for _, acc := range accounts {
+		if limiter != nil {
+			<-limiter
+		}
 		accountsCh <- acc
 	}
-	<-time.After(time.Second * 5)
-	log.Info("MakeUpdateBalances finished, duration: %s", time.Now().Sub(tn))
+	close(accountsCh)
+	wg.Wait()
+	return failed
 }
 
 func (s *ServiceFacade) updateAccount(account dmodels.Account) error {
@@ -57,13 +125,13 @@ func (s *ServiceFacade) updateAccount(account dmodels.Account) error {
 	if err != nil {
 		return fmt.Errorf("node.GetStake: %s", err.Error())
 	}
-	if balance.Equal(account.Balance) && stake.Equal(account.Stake) {
-		return nil
-	}
 	unbonding, err := s.node.GetUnbonding(account.Address)
 	if err != nil {
 		return fmt.Errorf("node.GetUnbonding: %s", err.Error())
 	}
+	if balance.Equal(account.Balance) && stake.Equal(account.Stake) && unbonding.Equal(account.Unbonding) {
+		return nil
+	}
 	account.Balance = balance
 	account.Stake = stake
 	account.Unbonding = unbonding
@@ -71,5 +139,111 @@ func (s *ServiceFacade) updateAccount(account dmodels.Account) error {
 	if err != nil {
 		return fmt.Errorf("dao.UpdateAccount: %s", err.Error())
 	}
+	tn := time.Now()
+	hash := sha1.Sum([]byte(fmt.Sprintf("%s.%d", account.Address, tn.Unix())))
+	err = s.dao.CreateBalanceUpdates([]dmodels.BalanceUpdate{{
+		ID:        hex.EncodeToString(hash[:]),
+		Address:   account.Address,
+		Balance:   balance,
+		Stake:     stake,
+		Unbonding: unbonding,
+		CreatedAt: tn,
+	}})
+	if err != nil {
+		return fmt.Errorf("dao.CreateBalanceUpdates: %s", err.Error())
+	}
 	return nil
 }
+
+// GetAccount returns the summary of the account, the balances are the ones kept by MakeUpdateBalances.
+func (s *ServiceFacade) GetAccount(address string) (account smodels.Account, err error) {
+	acc, err := s.dao.GetAccount(address)
+	if err != nil {
+		if err.Error() == derrors.ErrNotFound {
+			return account, err
+		}
+		return account, fmt.Errorf("dao.GetAccount: %s", err.Error())
+	}
+	account = smodels.Account{
+		Address:   acc.Address,
+		Balance:   acc.Balance,
+		Stake:     acc.Stake,
+		Unbonding: acc.Unbonding,
+		FirstSeen: dmodels.NewTime(acc.CreatedAt),
+	}
+	account.Delegations, err = s.dao.GetAccountDelegations(address)
+	if err != nil {
+		return account, fmt.Errorf("dao.GetAccountDelegations: %s", err.Error())
+	}
+	for i, delegation := range account.Delegations {
+		// the title is left empty while the validators cache is not filled
+		validator, err := s.GetValidator(delegation.Validator)
+		if err == nil {
+			account.Delegations[i].Title = validator.Title
+		}
+	}
+	account.RewardsWithdrawn, err = s.dao.GetDelegatorRewardsVolume(filters.Rewards{
+		Delegator: address,
+		Currency:  s.cfg.Chain.DisplayDenom,
+	})
+	if err != nil {
+		return account, fmt.Errorf("dao.GetDelegatorRewardsVolume: %s", err.Error())
+	}
+	account.GovernanceVotes, err = s.dao.GetTotalVotesByAddress(address)
+	if err != nil {
+		return account, fmt.Errorf("dao.GetTotalVotesByAddress: %s", err.Error())
+	}
+	return account, nil
+}
+
+func (s *ServiceFacade) GetAccountTransactions(filter filters.AccountTransactions) (resp smodels.PaginatableResponse, err error) {
+	items, err := s.dao.GetAccountTransactions(filter)
+	if err != nil {
+		return resp, fmt.Errorf("dao.GetAccountTransactions: %s", err.Error())
+	}
+	total, err := s.dao.GetAccountTransactionsTotal(filter)
+	if err != nil {
+		return resp, fmt.Errorf("dao.GetAccountTransactionsTotal: %s", err.Error())
+	}
+	for i, item := range items {
+		// delegations are kept without currency, they are always in the staking denom
+		if item.Type == smodels.AccountTransactionDelegation || item.Type == smodels.AccountTransactionUndelegation {
+			items[i].Currency = s.cfg.Chain.DisplayDenom
+			continue
+		}
+		items[i].Currency = s.displayCurrency(item.Currency)
+	}
+	return smodels.PaginatableResponse{
+		Items: items,
+		Total: total,
+	}, nil
+}
+
+// GetAccountBalanceAgg returns the balance of the account by periods,
+// the balance known before the range is put at its beginning so the chart has no leading gap.
+func (s *ServiceFacade) GetAccountBalanceAgg(filter filters.BalanceUpdatesAgg) (items []smodels.BalanceAggItem, err error) {
+	items, err = s.dao.GetAggBalanceUpdates(filter)
+	if err != nil {
+		return nil, fmt.Errorf("dao.GetAggBalanceUpdates: %s", err.Error())
+	}
+	if len(items) != 0 && !items[0].Time.After(filter.From.Time) {
+		return items, nil
+	}
+	updates, err := s.dao.GetBalanceUpdate(filters.BalanceUpdates{
+		TimeRange: filters.TimeRange{To: filter.From},
+		Address:   filter.Address,
+		Limit:     1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dao.GetBalanceUpdate: %s", err.Error())
+	}
+	if len(updates) == 0 {
+		return items, nil
+	}
+	return append([]smodels.BalanceAggItem{{
+		Time:      filter.From,
+		Balance:   updates[0].Balance,
+		Stake:     updates[0].Stake,
+		Unbonding: updates[0].Unbonding,
+	}}, items...), nil
+}
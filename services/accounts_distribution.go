@@ -0,0 +1,152 @@
+package services
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/kwanifi/numiscan-api/services/helpers"
+	"github.com/kwanifi/numiscan-api/smodels"
+	"github.com/shopspring/decimal"
+)
+
+const accountsDistributionCacheKey = "accounts_distribution"
+
+// concentrationTops are the numbers of the largest accounts the concentration is given for.
+var concentrationTops = []int{10, 100, 1000}
+
+func (s *ServiceFacade) GetTopAccounts(filter filters.TopAccounts) (resp smodels.PaginatableResponse, err error) {
+	accounts, err := s.dao.GetTopAccounts(filter)
+	if err != nil {
+		return resp, fmt.Errorf("dao.GetTopAccounts: %s", err.Error())
+	}
+	total, err := s.dao.GetAccountsTotal(filters.Accounts{})
+	if err != nil {
+		return resp, fmt.Errorf("dao.GetAccountsTotal: %s", err.Error())
+	}
+	items := make([]smodels.AccountBalance, len(accounts))
+	for i, account := range accounts {
+		items[i] = smodels.AccountBalance{
+			Address:   account.Address,
+			Balance:   account.Balance,
+			Stake:     account.Stake,
+			Unbonding: account.Unbonding,
+			Total:     account.Balance.Add(account.Stake).Add(account.Unbonding),
+		}
+	}
+	return smodels.PaginatableResponse{
+		Items: items,
+		Total: total,
+	}, nil
+}
+
+func (s *ServiceFacade) GetAccountsDistribution() (distribution smodels.AccountsDistribution, err error) {
+	data, found := s.dao.CacheGet(accountsDistributionCacheKey)
+	if found {
+		return data.(smodels.AccountsDistribution), nil
+	}
+	distribution, err = s.makeAccountsDistribution()
+	if err != nil {
+		return distribution, err
+	}
+	s.dao.CacheSet(accountsDistributionCacheKey, distribution, time.Minute*10)
+	return distribution, nil
+}
+
+// GetAggAccountsDistribution returns the daily snapshots of the number of accounts in every range.
+func (s *ServiceFacade) GetAggAccountsDistribution(filter filters.Agg) (items []smodels.DistributionRangeAgg, err error) {
+	bounds := s.cfg.Accounts.Distribution.Bounds()
+	for i, bound := range bounds {
+		aggItems, err := s.dao.GetAggStatsByTitle(filter, accountsDistributionTitle(i))
+		if err != nil {
+			return nil, fmt.Errorf("dao.GetAggStatsByTitle: %s", err.Error())
+		}
+		items = append(items, smodels.DistributionRangeAgg{
+			From:  bound,
+			To:    upperBound(bounds, i),
+			Items: aggItems,
+		})
+	}
+	return items, nil
+}
+
+func (s *ServiceFacade) makeAccountsDistribution() (distribution smodels.AccountsDistribution, err error) {
+	bounds := s.cfg.Accounts.Distribution.Bounds()
+	ranges, err := s.dao.GetAccountsDistribution(bounds)
+	if err != nil {
+		return distribution, fmt.Errorf("dao.GetAccountsDistribution: %s", err.Error())
+	}
+	distribution.Ranges = make([]smodels.DistributionRange, len(bounds))
+	for i, bound := range bounds {
+		distribution.Ranges[i] = smodels.DistributionRange{
+			From: bound,
+			To:   upperBound(bounds, i),
+		}
+	}
+	for _, r := range ranges {
+		if r.Index < 0 || r.Index >= len(bounds) {
+			return distribution, fmt.Errorf("unexpected range index %d", r.Index)
+		}
+		distribution.Ranges[r.Index].Accounts = r.Accounts
+		distribution.Ranges[r.Index].Amount = r.Amount
+	}
+	holdings, err := s.dao.GetAccountsHoldings()
+	if err != nil {
+		return distribution, fmt.Errorf("dao.GetAccountsHoldings: %s", err.Error())
+	}
+	distribution.Accounts = holdings.Accounts
+	distribution.Amount = holdings.Amount
+	distribution.Gini = helpers.GiniFromSums(holdings.Accounts, holdings.Amount, holdings.Weighted).Round(4)
+	for _, top := range concentrationTops {
+		share := decimal.Zero
+		if !holdings.Amount.IsZero() {
+			amount, err := s.dao.GetTopAccountsAmount(uint64(top))
+			if err != nil {
+				return distribution, fmt.Errorf("dao.GetTopAccountsAmount: %s", err.Error())
+			}
+			share = amount.Div(holdings.Amount).Mul(decimal.New(100, 0))
+		}
+		distribution.Concentration = append(distribution.Concentration, smodels.TopConcentration{
+			Accounts: uint64(top),
+			Share:    share.Round(2),
+		})
+	}
+	return distribution, nil
+}
+
+// makeAccountsDistributionStats returns the snapshot of the number of accounts in every range.
+func (s *ServiceFacade) makeAccountsDistributionStats(createdAt time.Time) ([]dmodels.Stat, error) {
+	distribution, err := s.makeAccountsDistribution()
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]dmodels.Stat, len(distribution.Ranges))
+	for i, r := range distribution.Ranges {
+		title := accountsDistributionTitle(i)
+		hash := sha1.Sum([]byte(fmt.Sprintf("%s.%s", title, createdAt.String())))
+		stats[i] = dmodels.Stat{
+			ID:        hex.EncodeToString(hash[:]),
+			Title:     title,
+			Value:     decimal.NewFromInt(int64(r.Accounts)),
+			CreatedAt: createdAt,
+		}
+	}
+	return stats, nil
+}
+
+// accountsDistributionTitle returns the stat title of the range by its index,
+// so the snapshots keep their series when the bounds are moved in the config.
+func accountsDistributionTitle(index int) string {
+	return dmodels.StatsAccountsDistribution + strconv.Itoa(index)
+}
+
+func upperBound(bounds []decimal.Decimal, i int) *decimal.Decimal {
+	if i == len(bounds)-1 {
+		return nil
+	}
+	return &bounds[i+1]
+}
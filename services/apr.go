@@ -0,0 +1,178 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kwanifi/numiscan-api/dao/derrors"
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/kwanifi/numiscan-api/services/helpers"
+	"github.com/kwanifi/numiscan-api/services/node"
+	"github.com/kwanifi/numiscan-api/smodels"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	stakingAPRCacheKey = "staking_apr"
+	networkAPRCacheKey = "network_apr"
+	realizedAPRPeriod  = time.Hour * 24 * 30
+	daysInYear         = 365
+)
+
+func (s *ServiceFacade) GetStakingAPR() (apr smodels.StakingAPR, err error) {
+	data, found := s.dao.CacheGet(stakingAPRCacheKey)
+	if found {
+		return data.(smodels.StakingAPR), nil
+	}
+	apr, err = s.getNetworkAPR()
+	if err != nil {
+		return apr, err
+	}
+	apr.RealizedAPR, err = s.getRealizedStakingAPR()
+	if err != nil {
+		return apr, fmt.Errorf("getRealizedStakingAPR: %s", err.Error())
+	}
+	s.dao.CacheSet(stakingAPRCacheKey, apr, time.Minute*10)
+	return apr, nil
+}
+
+// getNetworkAPR returns the cached estimation of makeStakingAPR without the realized APR.
+func (s *ServiceFacade) getNetworkAPR() (apr smodels.StakingAPR, err error) {
+	data, found := s.dao.CacheGet(networkAPRCacheKey)
+	if found {
+		return data.(smodels.StakingAPR), nil
+	}
+	apr, err = s.makeStakingAPR()
+	if err != nil {
+		return apr, fmt.Errorf("makeStakingAPR: %s", err.Error())
+	}
+	s.dao.CacheSet(networkAPRCacheKey, apr, time.Minute*10)
+	return apr, nil
+}
+
+// makeStakingAPR estimates the yield of the bonded tokens: the minted tokens without the community tax
+// are distributed to the bonded ones, the commission of a validator is taken by validatorAPR.
+func (s *ServiceFacade) makeStakingAPR() (apr smodels.StakingAPR, err error) {
+	apr.Inflation, err = s.node.GetInflation()
+	if err != nil {
+		return apr, fmt.Errorf("node.GetInflation: %s", err.Error())
+	}
+	params, err := s.node.GetDistributionParams()
+	if err != nil {
+		return apr, fmt.Errorf("node.GetDistributionParams: %s", err.Error())
+	}
+	apr.CommunityTax = params.Params.CommunityTax.Mul(decimal.NewFromInt(100))
+	totalSupply, err := s.node.GetTotalSupply()
+	if err != nil {
+		return apr, fmt.Errorf("node.GetTotalSupply: %s", err.Error())
+	}
+	stakingPool, err := s.node.GetStakingPool()
+	if err != nil {
+		return apr, fmt.Errorf("node.GetStakingPool: %s", err.Error())
+	}
+	if totalSupply.IsZero() || stakingPool.Pool.BondedTokens.IsZero() {
+		return apr, fmt.Errorf("total supply or bonded tokens is zero")
+	}
+	bondedRatio := stakingPool.Pool.BondedTokens.Div(totalSupply)
+	apr.BondedRatio = bondedRatio.Mul(decimal.NewFromInt(100)).Truncate(2)
+	apr.APR = apr.Inflation.Mul(decimal.NewFromInt(1).Sub(params.Params.CommunityTax)).Div(bondedRatio).Truncate(2)
+	return apr, nil
+}
+
+// getRealizedStakingAPR returns the yearly yield of the rewards withdrawn by delegators over the last 30 days.
+// The rewards left unclaimed are not counted, so it is below the estimation.
+func (s *ServiceFacade) getRealizedStakingAPR() (apr decimal.Decimal, err error) {
+	tn := time.Now()
+	rewards, err := s.dao.GetDelegatorRewardsVolume(filters.Rewards{
+		TimeRange: filters.TimeRange{
+			From: dmodels.NewTime(tn.Add(-realizedAPRPeriod)),
+			To:   dmodels.NewTime(tn),
+		},
+		Currency: s.cfg.Chain.DisplayDenom,
+	})
+	if err != nil {
+		return apr, fmt.Errorf("dao.GetDelegatorRewardsVolume: %s", err.Error())
+	}
+	stakingPool, err := s.node.GetStakingPool()
+	if err != nil {
+		return apr, fmt.Errorf("node.GetStakingPool: %s", err.Error())
+	}
+	if stakingPool.Pool.BondedTokens.IsZero() {
+		return apr, fmt.Errorf("bonded tokens is zero")
+	}
+	periodDays := decimal.NewFromFloat(realizedAPRPeriod.Hours() / 24)
+	return rewards.Div(stakingPool.Pool.BondedTokens).Div(periodDays).Mul(decimal.NewFromInt(daysInYear)).
+		Mul(decimal.NewFromInt(100)).Truncate(2), nil
+}
+
+// GetStakingCalculation projects the rewards of delegating the amount to the validator for the days.
+// The network APR is reduced by the commission, an unbonded validator earns nothing. The rewards are
+// projected once more with the APR reduced by the uptime over the signed blocks window.
+func (s *ServiceFacade) GetStakingCalculation(filter filters.StakingCalculator) (calc smodels.StakingCalculation, err error) {
+	validators, err := s.GetValidatorMap()
+	if err != nil {
+		return calc, fmt.Errorf("GetValidatorMap: %s", err.Error())
+	}
+	validator, ok := validators[filter.Validator]
+	if !ok {
+		return calc, fmt.Errorf(derrors.ErrNotFound)
+	}
+	stakingAPR, err := s.getNetworkAPR()
+	if err != nil {
+		return calc, fmt.Errorf("getNetworkAPR: %s", err.Error())
+	}
+	consAddress, err := helpers.GetHexAddressFromBase64PK(validator.ConsensusPubkey.Key)
+	if err != nil {
+		return calc, fmt.Errorf("helpers.GetHexAddressFromBase64PK: %s", err.Error())
+	}
+	window, err := s.getSignedBlocksWindow()
+	if err != nil {
+		return calc, fmt.Errorf("getSignedBlocksWindow: %s", err.Error())
+	}
+	latest, err := s.getLatestHeight()
+	if err != nil {
+		return calc, fmt.Errorf("getLatestHeight: %s", err.Error())
+	}
+	from, window := heightsWindow(latest, window)
+	active, err := s.getActiveRanges([]string{consAddress}, from, latest)
+	if err != nil {
+		return calc, fmt.Errorf("getActiveRanges: %s", err.Error())
+	}
+	missed, err := s.dao.GetMissedBlocksCount(filters.MissedBlocks{
+		Validators: []string{consAddress},
+		HeightFrom: from,
+		HeightTo:   latest,
+	})
+	if err != nil {
+		return calc, fmt.Errorf("dao.GetMissedBlocksCount: %s", err.Error())
+	}
+	commission := validator.Commission.CommissionRates.Rate
+	calc.Assumptions = smodels.StakingCalcAssumptions{
+		Inflation:    stakingAPR.Inflation,
+		CommunityTax: stakingAPR.CommunityTax,
+		BondedRatio:  stakingAPR.BondedRatio,
+		NetworkAPR:   stakingAPR.APR,
+		Commission:   commission.Mul(decimal.NewFromInt(100)),
+		Uptime:       uptimePercent(missed, helpers.RangesLength(active[consAddress])),
+		UptimeWindow: window,
+		Bonded:       validator.Status == node.BondedValidatorStatus,
+	}
+	calc.Amount = filter.Amount
+	calc.Days = filter.Days
+	calc.Compound = filter.Compound
+	if calc.Assumptions.Bonded {
+		calc.APR = validatorAPR(stakingAPR.APR, commission)
+	}
+	calc.Rewards = helpers.ProjectRewards(filter.Amount, calc.APR, filter.Days, filter.Compound)
+	calc.Total = calc.Amount.Add(calc.Rewards)
+	calc.UptimeAPR = helpers.UptimeAPR(calc.APR, calc.Assumptions.Uptime)
+	calc.UptimeRewards = helpers.ProjectRewards(filter.Amount, calc.UptimeAPR, filter.Days, filter.Compound)
+	calc.UptimeTotal = calc.Amount.Add(calc.UptimeRewards)
+	return calc, nil
+}
+
+// validatorAPR returns the yield of the delegators of the validator with the commission rate.
+func validatorAPR(networkAPR decimal.Decimal, commission decimal.Decimal) decimal.Decimal {
+	return networkAPR.Mul(decimal.NewFromInt(1).Sub(commission)).Truncate(2)
+}
@@ -3,13 +3,13 @@ package services
 import (
 	"fmt"
 
+	"github.com/kwanifi/numiscan-api/dao/derrors"
 	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/dmodels"
 	"github.com/kwanifi/numiscan-api/smodels"
-	"github.com/shopspring/decimal"
 )
 
 const topProposedBlocksValidatorsKey = "topProposedBlocksValidatorsKey"
-const rewardPerBlock = 4.0
 
 func (s *ServiceFacade) GetAggBlocksCount(filter filters.Agg) (items []smodels.AggItem, err error) {
 	items, err = s.dao.GetAggBlocksCount(filter)
@@ -52,6 +52,57 @@ func (s *ServiceFacade) GetValidatorBlocksStat(validatorAddress string) (stat sm
 	if err != nil {
 		return stat, fmt.Errorf("dao.GetMissedBlocksCount: %s", err.Error())
 	}
-	stat.Revenue = decimal.NewFromFloat(rewardPerBlock).Mul(decimal.NewFromInt(int64(stat.Proposed)))
+	stat.Revenue, err = s.dao.GetValidatorCommissionVolume(filters.ValidatorRevenues{Validator: validatorAddress})
+	if err != nil {
+		return stat, fmt.Errorf("dao.GetValidatorCommissionVolume: %s", err.Error())
+	}
 	return stat, nil
 }
+
+func (s *ServiceFacade) GetBlocks(filter filters.Blocks) (resp smodels.PaginatableResponse, err error) {
+	blocks, err := s.dao.GetBlocks(filter)
+	if err != nil {
+		return resp, fmt.Errorf("dao.GetBlocks: %s", err.Error())
+	}
+	total, err := s.dao.GetBlocksTotal(filter)
+	if err != nil {
+		return resp, fmt.Errorf("dao.GetBlocksTotal: %s", err.Error())
+	}
+	items := make([]smodels.Block, len(blocks))
+	for i, block := range blocks {
+		items[i] = makeBlock(block)
+	}
+	return smodels.PaginatableResponse{
+		Items: items,
+		Total: total,
+	}, nil
+}
+
+func (s *ServiceFacade) GetBlock(height uint64) (block smodels.BlockDetails, err error) {
+	blocks, err := s.dao.GetBlocks(filters.Blocks{Heights: []uint64{height}})
+	if err != nil {
+		return block, fmt.Errorf("dao.GetBlocks: %s", err.Error())
+	}
+	if len(blocks) == 0 {
+		return block, fmt.Errorf(derrors.ErrNotFound)
+	}
+	txs, err := s.dao.GetTransactions(filters.Transactions{Height: height})
+	if err != nil {
+		return block, fmt.Errorf("dao.GetTransactions: %s", err.Error())
+	}
+	block.Block = makeBlock(blocks[0])
+	block.Transactions = make([]smodels.Transaction, len(txs))
+	for i, tx := range txs {
+		block.Transactions[i] = makeTransaction(tx)
+	}
+	return block, nil
+}
+
+func makeBlock(block dmodels.Block) smodels.Block {
+	return smodels.Block{
+		Height:    block.ID,
+		Hash:      block.Hash,
+		Proposer:  block.Proposer,
+		CreatedAt: dmodels.NewTime(block.CreatedAt),
+	}
+}
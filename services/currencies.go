@@ -0,0 +1,12 @@
+package services
+
+import "github.com/kwanifi/numiscan-api/services/helpers"
+
+// displayCurrency returns the name the stored currency is shown under, the display names come from the config.
+func (s *ServiceFacade) displayCurrency(currency string) string {
+	displays := map[string]string{s.cfg.Chain.BaseDenom: s.cfg.Chain.DisplayDenom}
+	for _, d := range s.cfg.Parser.Denoms {
+		displays[d.Denom] = d.Display
+	}
+	return helpers.DisplayCurrency(currency, displays)
+}
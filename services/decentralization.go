@@ -0,0 +1,105 @@
+package services
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/kwanifi/numiscan-api/services/helpers"
+	"github.com/kwanifi/numiscan-api/services/node"
+	"github.com/kwanifi/numiscan-api/smodels"
+	"github.com/shopspring/decimal"
+)
+
+const decentralizationCacheKey = "decentralization"
+
+func (s *ServiceFacade) GetDecentralization() (report smodels.Decentralization, err error) {
+	data, found := s.dao.CacheGet(decentralizationCacheKey)
+	if found {
+		return data.(smodels.Decentralization), nil
+	}
+	report, err = s.makeDecentralization()
+	if err != nil {
+		return report, err
+	}
+	s.dao.CacheSet(decentralizationCacheKey, report, time.Minute*10)
+	return report, nil
+}
+
+// GetAggDecentralization returns the daily snapshots of the decentralization report.
+func (s *ServiceFacade) GetAggDecentralization(filter filters.Agg) (agg smodels.DecentralizationAgg, err error) {
+	series := []struct {
+		title string
+		items *[]smodels.AggItem
+	}{
+		{title: dmodels.StatsNakamoto33, items: &agg.Nakamoto33},
+		{title: dmodels.StatsNakamoto66, items: &agg.Nakamoto66},
+		{title: dmodels.StatsValidatorsGini, items: &agg.Gini},
+		{title: dmodels.StatsValidatorsHHI, items: &agg.HHI},
+		{title: dmodels.StatsBottomHalfShare, items: &agg.BottomHalfShare},
+	}
+	for _, item := range series {
+		*item.items, err = s.dao.GetAggStatsByTitle(filter, item.title)
+		if err != nil {
+			return agg, fmt.Errorf("dao.GetAggStatsByTitle: %s", err.Error())
+		}
+	}
+	return agg, nil
+}
+
+// makeDecentralization computes the report over the tokens of the bonded validators.
+func (s *ServiceFacade) makeDecentralization() (report smodels.Decentralization, err error) {
+	validators, err := s.GetValidatorMap()
+	if err != nil {
+		return report, fmt.Errorf("GetValidatorMap: %s", err.Error())
+	}
+	var powers []decimal.Decimal
+	for _, validator := range validators {
+		if validator.Status != node.BondedValidatorStatus {
+			continue
+		}
+		powers = append(powers, decimal.NewFromInt(int64(validator.Tokens)))
+	}
+	if len(powers) == 0 {
+		return report, fmt.Errorf("no bonded validators")
+	}
+	report.Validators = uint64(len(powers))
+	report.Nakamoto33 = uint64(helpers.Nakamoto(powers, 1, 3))
+	report.Nakamoto66 = uint64(helpers.Nakamoto(powers, 2, 3))
+	report.Gini = helpers.Gini(powers).Round(4)
+	report.HHI = helpers.HHI(powers).Round(2)
+	report.BottomHalfShare = helpers.BottomShare(powers, len(powers)/2).Round(2)
+	return report, nil
+}
+
+// makeDecentralizationStats returns the snapshot of the decentralization report.
+func (s *ServiceFacade) makeDecentralizationStats(createdAt time.Time) ([]dmodels.Stat, error) {
+	report, err := s.makeDecentralization()
+	if err != nil {
+		return nil, err
+	}
+	values := []struct {
+		title string
+		value decimal.Decimal
+	}{
+		{title: dmodels.StatsNakamoto33, value: decimal.NewFromInt(int64(report.Nakamoto33))},
+		{title: dmodels.StatsNakamoto66, value: decimal.NewFromInt(int64(report.Nakamoto66))},
+		{title: dmodels.StatsValidatorsGini, value: report.Gini},
+		{title: dmodels.StatsValidatorsHHI, value: report.HHI},
+		{title: dmodels.StatsBottomHalfShare, value: report.BottomHalfShare},
+	}
+	stats := make([]dmodels.Stat, len(values))
+	for i, item := range values {
+		hash := sha1.Sum([]byte(fmt.Sprintf("%s.%s", item.title, createdAt.String())))
+		stats[i] = dmodels.Stat{
+			ID:        hex.EncodeToString(hash[:]),
+			Title:     item.title,
+			Value:     item.value,
+			CreatedAt: createdAt,
+		}
+	}
+	return stats, nil
+}
@@ -26,24 +26,48 @@ func (s *ServiceFacade) GetAggUndelegationsVolume(filter filters.Agg) (items []s
 	return items, nil
 }
 
+// GetAggUnbondingVolume returns the tokens in the unbonding queue at the end of every period,
+// the current queue for the period which is not over yet.
 func (s *ServiceFacade) GetAggUnbondingVolume(filter filters.Agg) (items []smodels.AggItem, err error) {
-	undelegationItems, err := s.dao.GetAggUndelegationsVolume(filter)
+	changes, err := s.dao.GetAggUnbondingChanges(filter)
 	if err != nil {
-		return nil, fmt.Errorf("dao.GetAggUndelegationsVolume: %s", err.Error())
+		return nil, fmt.Errorf("dao.GetAggUnbondingChanges: %s", err.Error())
 	}
-	items = make([]smodels.AggItem, len(undelegationItems))
-	for i, item := range undelegationItems {
-		total, err := s.dao.GetUndelegationsVolume(filters.TimeRange{
-			From: dmodels.NewTime(item.Time.Add(-time.Hour * 24 * 21)),
-			To:   item.Time,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("dao.GetUndelegationsVolume: %s", err.Error())
-		}
-		items[i] = smodels.AggItem{
-			Time:  item.Time,
-			Value: total,
+	queue := decimal.Zero
+	next := 0
+	for _, period := range filter.Periods() {
+		for next < len(changes) && !changes[next].Time.After(period) {
+			queue = queue.Add(changes[next].Value)
+			next++
 		}
+		items = append(items, smodels.AggItem{
+			Time:  dmodels.NewTime(period),
+			Value: queue,
+		})
+	}
+	return items, nil
+}
+
+func (s *ServiceFacade) GetUnbondingSchedule(filter filters.UnbondingSchedule) (items []smodels.AggItem, err error) {
+	items, err = s.dao.GetUnbondingSchedule(filter)
+	if err != nil {
+		return nil, fmt.Errorf("dao.GetUnbondingSchedule: %s", err.Error())
+	}
+	return items, nil
+}
+
+func (s *ServiceFacade) GetRedelegationFlows(filter filters.RedelegationFlows) (items []smodels.RedelegationFlow, err error) {
+	items, err = s.dao.GetRedelegationFlows(filter)
+	if err != nil {
+		return nil, fmt.Errorf("dao.GetRedelegationFlows: %s", err.Error())
+	}
+	validators, err := s.GetValidatorMap()
+	if err != nil {
+		return nil, fmt.Errorf("GetValidatorMap: %s", err.Error())
+	}
+	for i := range items {
+		items[i].SourceTitle = validators[items[i].Source].Description.Moniker
+		items[i].DestinationTitle = validators[items[i].Destination].Description.Moniker
 	}
 	return items, nil
 }
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kwanifi/numiscan-api/dao/derrors"
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/kwanifi/numiscan-api/services/helpers"
+	"github.com/kwanifi/numiscan-api/services/node"
+	"github.com/kwanifi/numiscan-api/smodels"
+	"github.com/shopspring/decimal"
+)
+
+// endedProposalStatuses are the statuses of the proposals which have finished the voting period.
+var endedProposalStatuses = map[string]bool{
+	dmodels.ProposalStatusPassed:   true,
+	dmodels.ProposalStatusRejected: true,
+	dmodels.ProposalStatusFailed:   true,
+}
+
+// GetValidatorVotes returns the vote of the validator on every proposal which reached the voting period
+// and the participation of the validator over the last `filter.Proposals` ended ones.
+func (s *ServiceFacade) GetValidatorVotes(validatorAddress string, filter filters.Participation) (votes smodels.ValidatorVotes, err error) {
+	validators, err := s.GetValidatorMap()
+	if err != nil {
+		return votes, fmt.Errorf("GetValidatorMap: %s", err.Error())
+	}
+	if _, ok := validators[validatorAddress]; !ok {
+		return votes, fmt.Errorf(derrors.ErrNotFound)
+	}
+	account, err := helpers.ConvertBech32(validatorAddress, s.cfg.Chain.ValidatorPrefix, s.cfg.Chain.AccountPrefix)
+	if err != nil {
+		return votes, fmt.Errorf("helpers.ConvertBech32: %s", err.Error())
+	}
+	proposals, err := s.dao.GetProposals(filters.Proposals{})
+	if err != nil {
+		return votes, fmt.Errorf("dao.GetProposals: %s", err.Error())
+	}
+	var ids []uint64
+	votingEndTimes := make(map[uint64]time.Time)
+	for _, proposal := range proposals {
+		if proposal.Status != dmodels.ProposalStatusDepositPeriod {
+			ids = append(ids, proposal.ID)
+			votingEndTimes[proposal.ID] = proposal.VotingEndTime.Time
+		}
+	}
+	votes.Votes = []smodels.ValidatorVote{}
+	if len(ids) == 0 {
+		return votes, nil
+	}
+	lastVotes, err := s.dao.GetLastVotes(filters.LastVotes{ProposalIDs: ids, Voters: []string{account}})
+	if err != nil {
+		return votes, fmt.Errorf("dao.GetLastVotes: %s", err.Error())
+	}
+	voted := make(map[uint64]smodels.LastVote)
+	for _, vote := range lastVotes {
+		voted[vote.ProposalID] = vote
+	}
+	overrides, err := s.dao.GetDelegatorOverrides(filters.DelegatorOverrides{
+		VotingEndTimes:   votingEndTimes,
+		Validator:        validatorAddress,
+		ValidatorAccount: account,
+	})
+	if err != nil {
+		return votes, fmt.Errorf("dao.GetDelegatorOverrides: %s", err.Error())
+	}
+	overridden := make(map[uint64]uint64)
+	for _, item := range overrides {
+		overridden[item.ProposalID] = item.Value
+	}
+	for _, proposal := range proposals {
+		if proposal.Status == dmodels.ProposalStatusDepositPeriod {
+			continue
+		}
+		item := smodels.ValidatorVote{
+			ProposalID:         proposal.ID,
+			Title:              proposal.Title,
+			Status:             proposal.Status,
+			VotingEndTime:      proposal.VotingEndTime,
+			Option:             dmodels.ProposalVoteDidNotVote,
+			DelegatorOverrides: overridden[proposal.ID],
+			Overridden:         overridden[proposal.ID] > 0,
+		}
+		if vote, ok := voted[proposal.ID]; ok {
+			item.Option = vote.Option
+			item.TxHash = vote.TxHash
+			item.VotedAt = vote.VotedAt
+		}
+		votes.Votes = append(votes.Votes, item)
+	}
+	ended := lastEndedProposals(proposals, filter.Proposals)
+	votes.Participation = participation(ended, voted)
+	return votes, nil
+}
+
+// GetGovernanceValidators returns the bonded validators ranked by the participation
+// over the last `filter.Proposals` proposals ended in voting.
+func (s *ServiceFacade) GetGovernanceValidators(filter filters.Participation) (items []smodels.ValidatorParticipation, err error) {
+	validators, err := s.GetValidatorMap()
+	if err != nil {
+		return nil, fmt.Errorf("GetValidatorMap: %s", err.Error())
+	}
+	proposals, err := s.dao.GetProposals(filters.Proposals{})
+	if err != nil {
+		return nil, fmt.Errorf("dao.GetProposals: %s", err.Error())
+	}
+	ended := lastEndedProposals(proposals, filter.Proposals)
+	accounts := make(map[string]string)
+	var voters []string
+	for _, validator := range validators {
+		if validator.Status != node.BondedValidatorStatus {
+			continue
+		}
+		account, err := helpers.ConvertBech32(validator.OperatorAddress, s.cfg.Chain.ValidatorPrefix, s.cfg.Chain.AccountPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("helpers.ConvertBech32: %s", err.Error())
+		}
+		accounts[validator.OperatorAddress] = account
+		voters = append(voters, account)
+	}
+	voted := make(map[string]map[uint64]smodels.LastVote)
+	if len(ended) != 0 && len(voters) != 0 {
+		lastVotes, err := s.dao.GetLastVotes(filters.LastVotes{ProposalIDs: ended, Voters: voters})
+		if err != nil {
+			return nil, fmt.Errorf("dao.GetLastVotes: %s", err.Error())
+		}
+		for _, vote := range lastVotes {
+			if voted[vote.Voter] == nil {
+				voted[vote.Voter] = make(map[uint64]smodels.LastVote)
+			}
+			voted[vote.Voter][vote.ProposalID] = vote
+		}
+	}
+	items = make([]smodels.ValidatorParticipation, 0, len(accounts))
+	for operatorAddress, account := range accounts {
+		items = append(items, smodels.ValidatorParticipation{
+			OperatorAddress: operatorAddress,
+			Title:           validators[operatorAddress].Description.Moniker,
+			Participation:   participation(ended, voted[account]),
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].Score.Equal(items[j].Score) {
+			return items[i].Score.GreaterThan(items[j].Score)
+		}
+		return items[i].Title < items[j].Title
+	})
+	return items, nil
+}
+
+// lastEndedProposals returns the ids of the last n proposals ended in voting, the proposals are ordered by id desc.
+func lastEndedProposals(proposals []dmodels.Proposal, n uint64) (ids []uint64) {
+	for _, proposal := range proposals {
+		if uint64(len(ids)) == n {
+			break
+		}
+		if endedProposalStatuses[proposal.Status] {
+			ids = append(ids, proposal.ID)
+		}
+	}
+	return ids
+}
+
+func participation(proposals []uint64, voted map[uint64]smodels.LastVote) (p smodels.Participation) {
+	p.Proposals = uint64(len(proposals))
+	for _, id := range proposals {
+		if _, ok := voted[id]; ok {
+			p.Voted++
+		}
+	}
+	if p.Proposals != 0 {
+		p.Score = decimal.NewFromInt(int64(p.Voted)).Div(decimal.NewFromInt(int64(p.Proposals))).Mul(decimal.NewFromInt(100)).Truncate(2)
+	}
+	return p
+}
@@ -0,0 +1,34 @@
+package helpers
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+// ConvertBech32 encodes the bytes of a bech32 address with another prefix,
+// for example an operator address into the account address of the validator.
+func ConvertBech32(address string, fromPrefix string, toPrefix string) (string, error) {
+	bz, err := types.GetFromBech32(address, fromPrefix)
+	if err != nil {
+		return "", fmt.Errorf("types.GetFromBech32: %s", err.Error())
+	}
+	result, err := bech32.ConvertAndEncode(toPrefix, bz)
+	if err != nil {
+		return "", fmt.Errorf("bech32.ConvertAndEncode: %s", err.Error())
+	}
+	return result, nil
+}
+
+// GetHexAddressFromBech32 returns the address bytes as upper case hex,
+// the way consensus addresses are shown in blocks.
+func GetHexAddressFromBech32(address string) (string, error) {
+	_, bz, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return "", fmt.Errorf("bech32.DecodeAndConvert: %s", err.Error())
+	}
+	return strings.ToUpper(hex.EncodeToString(bz)), nil
+}
@@ -0,0 +1,38 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SplitDenomTrace splits a denom trace like `transfer/channel-0/uatom` into the port/channel path and the base denom.
+func SplitDenomTrace(trace string) (path string, base string) {
+	parts := strings.Split(trace, "/")
+	i := 0
+	for ; i+1 < len(parts); i += 2 {
+		if !strings.HasPrefix(parts[i+1], "channel-") {
+			break
+		}
+	}
+	return strings.Join(parts[:i], "/"), strings.Join(parts[i:], "/")
+}
+
+// DisplayCurrency returns the name a stored currency is shown under. The display names are looked up by the base denom,
+// the channels of an IBC voucher are added to it, so the vouchers are told apart from the native denom.
+func DisplayCurrency(currency string, displays map[string]string) string {
+	path, base := SplitDenomTrace(currency)
+	display, ok := displays[base]
+	if !ok {
+		display = base
+	}
+	if path == "" {
+		return display
+	}
+	var channels []string
+	for _, part := range strings.Split(path, "/") {
+		if strings.HasPrefix(part, "channel-") {
+			channels = append(channels, part)
+		}
+	}
+	return fmt.Sprintf("%s (%s)", display, strings.Join(channels, "/"))
+}
@@ -0,0 +1,25 @@
+package helpers
+
+import "testing"
+
+func TestSplitDenomTrace(t *testing.T) {
+	if path, base := SplitDenomTrace("transfer/channel-1/transfer/channel-0/uatom"); path != "transfer/channel-1/transfer/channel-0" || base != "uatom" {
+		t.Error("wrong trace", path, base)
+	}
+	if path, base := SplitDenomTrace("gamm/pool/1"); path != "" || base != "gamm/pool/1" {
+		t.Error("wrong native denom", path, base)
+	}
+}
+
+func TestDisplayCurrency(t *testing.T) {
+	displays := map[string]string{"uatom": "atom"}
+	if c := DisplayCurrency("atom", displays); c != "atom" {
+		t.Error("wrong staking currency", c)
+	}
+	if c := DisplayCurrency("transfer/channel-1/transfer/channel-0/uatom", displays); c != "atom (channel-1/channel-0)" {
+		t.Error("wrong voucher currency", c)
+	}
+	if c := DisplayCurrency("uosmo", displays); c != "uosmo" {
+		t.Error("unknown denom should be shown as is", c)
+	}
+}
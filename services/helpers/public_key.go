@@ -3,6 +3,10 @@ package helpers
 import (
 	"encoding/base64"
 	"fmt"
+
+	sdked25519 "github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 )
 
@@ -17,3 +21,26 @@ func GetHexAddressFromBase64PK(key string) (address string, err error) {
 	pub := ed25519.PubKey(decodedKey)
 	return pub.Address().String(), nil
 }
+
+// GetAddressFromPubKey returns the bech32 address of a secp256k1 or ed25519 public key,
+// keyType is the type url of the key.
+func GetAddressFromPubKey(keyType string, key string, prefix string) (address string, err error) {
+	decodedKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return address, fmt.Errorf("base64.DecodeString: %s", err.Error())
+	}
+	var bz []byte
+	switch keyType {
+	case "/cosmos.crypto.secp256k1.PubKey":
+		bz = (&secp256k1.PubKey{Key: decodedKey}).Address()
+	case "/cosmos.crypto.ed25519.PubKey":
+		bz = (&sdked25519.PubKey{Key: decodedKey}).Address()
+	default:
+		return address, fmt.Errorf("unsupported key type %s", keyType)
+	}
+	address, err = bech32.ConvertAndEncode(prefix, bz)
+	if err != nil {
+		return address, fmt.Errorf("bech32.ConvertAndEncode: %s", err.Error())
+	}
+	return address, nil
+}
@@ -0,0 +1,27 @@
+package helpers
+
+import "github.com/shopspring/decimal"
+
+const (
+	daysInYear      = 365
+	rewardPrecision = 18
+)
+
+// ProjectRewards returns the rewards of the amount staked for the days with the yearly yield apr in percents.
+// With compound the rewards are restaked every day.
+func ProjectRewards(amount decimal.Decimal, apr decimal.Decimal, days uint64, compound bool) decimal.Decimal {
+	if !compound {
+		return amount.Mul(apr).Mul(decimal.NewFromInt(int64(days))).Div(hundred).Div(decimal.NewFromInt(daysInYear)).Truncate(rewardPrecision)
+	}
+	dailyRate := apr.Div(hundred).Div(decimal.NewFromInt(daysInYear))
+	total := amount
+	for i := uint64(0); i < days; i++ {
+		total = total.Add(total.Mul(dailyRate)).Truncate(rewardPrecision)
+	}
+	return total.Sub(amount)
+}
+
+// UptimeAPR returns the yield apr reduced by the uptime in percents.
+func UptimeAPR(apr decimal.Decimal, uptime decimal.Decimal) decimal.Decimal {
+	return apr.Mul(uptime).Div(hundred).Truncate(2)
+}
@@ -0,0 +1,45 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestProjectRewards(t *testing.T) {
+	amount := decimal.NewFromInt(1000)
+	apr := decimal.NewFromInt(10)
+
+	simple := ProjectRewards(amount, apr, 365, false)
+	if !simple.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("simple rewards: got %s, want 100", simple)
+	}
+	compound := ProjectRewards(amount, apr, 365, true)
+	if compound.LessThanOrEqual(simple) {
+		t.Errorf("compound rewards %s should be above simple %s", compound, simple)
+	}
+	// 1000 * ((1 + 0.1/365)^365 - 1) = 105.155...
+	if !compound.Truncate(2).Equal(decimal.RequireFromString("105.15")) {
+		t.Errorf("compound rewards: got %s, want 105.15", compound.Truncate(2))
+	}
+	if !ProjectRewards(amount, decimal.Zero, 30, true).IsZero() {
+		t.Errorf("rewards with zero apr should be zero")
+	}
+}
+
+func TestUptimeAPR(t *testing.T) {
+	apr := decimal.NewFromInt(10)
+	if got := UptimeAPR(apr, decimal.NewFromInt(100)); !got.Equal(apr) {
+		t.Errorf("full uptime: got %s, want 10", got)
+	}
+	if got := UptimeAPR(apr, decimal.RequireFromString("95.5")); !got.Equal(decimal.RequireFromString("9.55")) {
+		t.Errorf("got %s, want 9.55", got)
+	}
+	adjusted := ProjectRewards(decimal.NewFromInt(1000), UptimeAPR(apr, decimal.NewFromInt(50)), 365, false)
+	if !adjusted.Equal(decimal.NewFromInt(50)) {
+		t.Errorf("rewards at half uptime: got %s, want 50", adjusted)
+	}
+	if !UptimeAPR(apr, decimal.Zero).IsZero() {
+		t.Errorf("zero uptime should give zero apr")
+	}
+}
@@ -0,0 +1,114 @@
+package helpers
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+var hundred = decimal.New(100, 0)
+
+// Gini returns the Gini coefficient of the values, from 0 for the equal values to 1 when a single value holds everything.
+func Gini(values []decimal.Decimal) decimal.Decimal {
+	sorted := sortedAsc(values)
+	sum := decimal.Zero
+	weighted := decimal.Zero
+	for i, value := range sorted {
+		sum = sum.Add(value)
+		weighted = weighted.Add(value.Mul(decimal.New(int64(i+1), 0)))
+	}
+	return GiniFromSums(uint64(len(sorted)), sum, weighted)
+}
+
+// GiniFromSums returns the Gini coefficient of n values by their sum and Σ(i * x_i) over the values sorted ascending.
+func GiniFromSums(n uint64, sum decimal.Decimal, weighted decimal.Decimal) decimal.Decimal {
+	if n == 0 || sum.IsZero() {
+		return decimal.Zero
+	}
+	count := decimal.NewFromInt(int64(n))
+	// G = 2 * Σ(i * x_i) / (n * Σx) - (n + 1) / n
+	return weighted.Mul(decimal.New(2, 0)).Div(count.Mul(sum)).Sub(count.Add(decimal.New(1, 0)).Div(count))
+}
+
+// TopShare returns the percent of the sum held by the n largest values.
+func TopShare(values []decimal.Decimal, n int) decimal.Decimal {
+	sorted := sortedAsc(values)
+	sum := decimal.Zero
+	top := decimal.Zero
+	for i, value := range sorted {
+		sum = sum.Add(value)
+		if i >= len(sorted)-n {
+			top = top.Add(value)
+		}
+	}
+	if sum.IsZero() {
+		return decimal.Zero
+	}
+	return top.Div(sum).Mul(hundred)
+}
+
+// BottomShare returns the percent of the sum held by the n smallest values.
+func BottomShare(values []decimal.Decimal, n int) decimal.Decimal {
+	sorted := sortedAsc(values)
+	sum := decimal.Zero
+	bottom := decimal.Zero
+	for i, value := range sorted {
+		sum = sum.Add(value)
+		if i < n {
+			bottom = bottom.Add(value)
+		}
+	}
+	if sum.IsZero() {
+		return decimal.Zero
+	}
+	return bottom.Div(sum).Mul(hundred)
+}
+
+// Nakamoto returns the smallest number of the largest values holding more than numerator/denominator of the sum,
+// the comparison is exact: held * denominator > sum * numerator.
+func Nakamoto(values []decimal.Decimal, numerator int64, denominator int64) int {
+	sorted := sortedAsc(values)
+	sum := decimal.Zero
+	for _, value := range sorted {
+		sum = sum.Add(value)
+	}
+	if sum.IsZero() || denominator == 0 {
+		return 0
+	}
+	limit := sum.Mul(decimal.NewFromInt(numerator))
+	held := decimal.Zero
+	for i := len(sorted) - 1; i >= 0; i-- {
+		held = held.Add(sorted[i])
+		if held.Mul(decimal.NewFromInt(denominator)).GreaterThan(limit) {
+			return len(sorted) - i
+		}
+	}
+	return len(sorted)
+}
+
+// HHI returns the Herfindahl–Hirschman index of the values: the sum of the squared shares in percents,
+// from 10000 / n for the equal values to 10000 when a single value holds everything.
+func HHI(values []decimal.Decimal) decimal.Decimal {
+	sum := decimal.Zero
+	for _, value := range values {
+		sum = sum.Add(value)
+	}
+	if sum.IsZero() {
+		return decimal.Zero
+	}
+	index := decimal.Zero
+	for _, value := range values {
+		share := value.Div(sum).Mul(hundred)
+		index = index.Add(share.Mul(share))
+	}
+	return index
+}
+
+func sortedAsc(values []decimal.Decimal) []decimal.Decimal {
+	sorted := make([]decimal.Decimal, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].LessThan(sorted[j])
+	})
+	return sorted
+}
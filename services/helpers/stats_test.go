@@ -0,0 +1,91 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func decimals(values ...int64) []decimal.Decimal {
+	items := make([]decimal.Decimal, len(values))
+	for i, value := range values {
+		items[i] = decimal.NewFromInt(value)
+	}
+	return items
+}
+
+func TestGini(t *testing.T) {
+	if g := Gini(decimals(5, 5, 5, 5)); !g.IsZero() {
+		t.Error("equal values should give zero", g)
+	}
+	if g := Gini(decimals(0, 0, 0, 10)); !g.Equal(decimal.NewFromFloat(0.75)) {
+		t.Error("wrong coefficient for a single holder", g)
+	}
+	if g := Gini(decimals(1, 2, 3, 4)); !g.Equal(decimal.NewFromFloat(0.25)) {
+		t.Error("wrong coefficient", g)
+	}
+	if g := Gini(nil); !g.IsZero() {
+		t.Error("no values should give zero", g)
+	}
+}
+
+func TestTopShare(t *testing.T) {
+	if s := TopShare(decimals(1, 4, 2, 3), 1); !s.Equal(decimal.NewFromInt(40)) {
+		t.Error("wrong share of the top one", s)
+	}
+	if s := TopShare(decimals(1, 4, 2, 3), 2); !s.Equal(decimal.NewFromInt(70)) {
+		t.Error("wrong share of the top two", s)
+	}
+	if s := TopShare(decimals(1, 4), 10); !s.Equal(decimal.NewFromInt(100)) {
+		t.Error("n above the number of values should give everything", s)
+	}
+	if s := TopShare(decimals(0, 0), 1); !s.IsZero() {
+		t.Error("zero sum should give zero", s)
+	}
+}
+
+func TestBottomShare(t *testing.T) {
+	if s := BottomShare(decimals(1, 4, 2, 3), 2); !s.Equal(decimal.NewFromInt(30)) {
+		t.Error("wrong share of the bottom two", s)
+	}
+	if s := BottomShare(nil, 2); !s.IsZero() {
+		t.Error("no values should give zero", s)
+	}
+}
+
+func TestNakamoto(t *testing.T) {
+	if n := Nakamoto(decimals(10, 40, 20, 30), 1, 3); n != 1 {
+		t.Error("wrong coefficient for 1/3", n)
+	}
+	if n := Nakamoto(decimals(10, 40, 20, 30), 2, 3); n != 2 {
+		t.Error("wrong coefficient for 2/3", n)
+	}
+	if n := Nakamoto(decimals(25, 25, 25, 25), 1, 2); n != 3 {
+		t.Error("half of the equal values should not be enough", n)
+	}
+	// 34 of 100 is above 1/3 and 33.4 of 100 too, 33 is not
+	if n := Nakamoto(decimals(33, 33, 34), 1, 3); n != 1 {
+		t.Error("34% should be above 1/3", n)
+	}
+	if n := Nakamoto(decimals(1, 1, 1), 1, 3); n != 2 {
+		t.Error("exactly 1/3 should not be enough", n)
+	}
+	if n := Nakamoto(decimals(1, 1, 1), 2, 3); n != 3 {
+		t.Error("exactly 2/3 should not be enough", n)
+	}
+	if n := Nakamoto(nil, 1, 3); n != 0 {
+		t.Error("no values should give zero", n)
+	}
+}
+
+func TestHHI(t *testing.T) {
+	if h := HHI(decimals(5, 5, 5, 5)); !h.Equal(decimal.NewFromInt(2500)) {
+		t.Error("wrong index for equal values", h)
+	}
+	if h := HHI(decimals(0, 10)); !h.Equal(decimal.NewFromInt(10000)) {
+		t.Error("wrong index for a single holder", h)
+	}
+	if h := HHI(nil); !h.IsZero() {
+		t.Error("no values should give zero", h)
+	}
+}
@@ -0,0 +1,68 @@
+package helpers
+
+// MissedStreaks returns the longest run of consecutive heights and the run ending at the latest height,
+// the heights should be distinct and sorted in ascending order.
+func MissedStreaks(heights []uint64, latest uint64) (longest uint64, current uint64) {
+	var streak uint64
+	for i, height := range heights {
+		if i > 0 && height == heights[i-1]+1 {
+			streak++
+		} else {
+			streak = 1
+		}
+		if streak > longest {
+			longest = streak
+		}
+	}
+	if len(heights) != 0 && heights[len(heights)-1] == latest {
+		current = streak
+	}
+	return longest, current
+}
+
+// HeightsRange is the inclusive range of heights.
+type HeightsRange struct {
+	From uint64
+	To   uint64
+}
+
+// PowerChange is the voting power of the validator from the height.
+type PowerChange struct {
+	Height uint64
+	Power  uint64
+}
+
+// ActiveRanges returns the ranges of heights from `from` to `to` where the power is not zero,
+// initial is the power before `from` and the changes should be sorted by height.
+func ActiveRanges(initial uint64, changes []PowerChange, from uint64, to uint64) (ranges []HeightsRange) {
+	power := initial
+	start := from
+	for _, change := range changes {
+		if change.Height > to {
+			break
+		}
+		if change.Height <= from {
+			power = change.Power
+			continue
+		}
+		if power != 0 && change.Power == 0 {
+			ranges = append(ranges, HeightsRange{From: start, To: change.Height - 1})
+		}
+		if power == 0 && change.Power != 0 {
+			start = change.Height
+		}
+		power = change.Power
+	}
+	if power != 0 {
+		ranges = append(ranges, HeightsRange{From: start, To: to})
+	}
+	return ranges
+}
+
+// RangesLength returns the number of heights in the ranges.
+func RangesLength(ranges []HeightsRange) (n uint64) {
+	for _, r := range ranges {
+		n += r.To - r.From + 1
+	}
+	return n
+}
@@ -0,0 +1,40 @@
+package helpers
+
+import "testing"
+
+func TestMissedStreaks(t *testing.T) {
+	longest, current := MissedStreaks([]uint64{3, 4, 5, 9, 11, 12}, 12)
+	if longest != 3 || current != 2 {
+		t.Error("wrong streaks", longest, current)
+	}
+	longest, current = MissedStreaks([]uint64{3, 4, 5, 9}, 12)
+	if longest != 3 || current != 0 {
+		t.Error("the current streak should be zero when the latest height is signed", longest, current)
+	}
+	longest, current = MissedStreaks(nil, 12)
+	if longest != 0 || current != 0 {
+		t.Error("no heights should give no streaks", longest, current)
+	}
+}
+
+func TestActiveRanges(t *testing.T) {
+	changes := []PowerChange{{Height: 10, Power: 5}, {Height: 15, Power: 0}, {Height: 18, Power: 7}, {Height: 19, Power: 8}, {Height: 30, Power: 0}}
+	ranges := ActiveRanges(0, changes, 1, 25)
+	if len(ranges) != 2 || ranges[0] != (HeightsRange{From: 10, To: 14}) || ranges[1] != (HeightsRange{From: 18, To: 25}) {
+		t.Error("wrong ranges", ranges)
+	}
+	if RangesLength(ranges) != 13 {
+		t.Error("wrong length", RangesLength(ranges))
+	}
+	ranges = ActiveRanges(3, changes, 12, 16)
+	if len(ranges) != 1 || ranges[0] != (HeightsRange{From: 12, To: 14}) {
+		t.Error("the initial power should open the first range", ranges)
+	}
+	ranges = ActiveRanges(3, changes, 1, 9)
+	if len(ranges) != 1 || ranges[0] != (HeightsRange{From: 1, To: 9}) {
+		t.Error("no changes in the range should keep the initial power", ranges)
+	}
+	if len(ActiveRanges(0, nil, 1, 9)) != 0 {
+		t.Error("no power should give no ranges")
+	}
+}
@@ -6,11 +6,9 @@ import (
 	"strings"
 	"time"
 
-	"github.com/kwanifi/numiscan-api/config"
 	"github.com/kwanifi/numiscan-api/dao/filters"
 	"github.com/kwanifi/numiscan-api/dmodels"
 	"github.com/kwanifi/numiscan-api/log"
-	"github.com/kwanifi/numiscan-api/services/node"
 	"github.com/kwanifi/numiscan-api/smodels"
 	"github.com/shopspring/decimal"
 )
@@ -86,7 +84,7 @@ func (s ServiceFacade) makeState() (state dmodels.HistoricalState, err error) {
 		for i := 0; i < 20; i++ {
 			top20Stake = top20Stake.Add(validators[i].DelegatorShares)
 		}
-		top20Stake = top20Stake.Div(node.PrecisionDiv)
+		top20Stake = top20Stake.Div(s.cfg.Chain.PrecisionDiv())
 		if !stakingPool.Pool.BondedTokens.IsZero() {
 			state.Top20Weight = top20Stake.Div(stakingPool.Pool.BondedTokens).Mul(decimal.New(100, 0)).Truncate(2)
 		}
@@ -99,7 +97,7 @@ func (s ServiceFacade) makeState() (state dmodels.HistoricalState, err error) {
 		return state, fmt.Errorf("cmc.GetCurrencies: %s", err.Error())
 	}
 	for _, currency := range currencies {
-		if strings.ToLower(currency.Symbol) == config.Currency {
+		if strings.ToLower(currency.Symbol) == s.cfg.Chain.DisplayDenom {
 			quote, ok := currency.Quote["USD"]
 			if !ok {
 				return state, fmt.Errorf("not found USD quote")
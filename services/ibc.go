@@ -0,0 +1,37 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/smodels"
+)
+
+func (s *ServiceFacade) GetIBCTransfers(filter filters.IBCTransfers) (resp smodels.PaginatableResponse, err error) {
+	items, err := s.dao.GetIBCTransfers(filter)
+	if err != nil {
+		return resp, fmt.Errorf("dao.GetIBCTransfers: %s", err.Error())
+	}
+	total, err := s.dao.GetIBCTransfersTotal(filter)
+	if err != nil {
+		return resp, fmt.Errorf("dao.GetIBCTransfersTotal: %s", err.Error())
+	}
+	for i, item := range items {
+		items[i].Denom = s.displayCurrency(item.Denom)
+	}
+	return smodels.PaginatableResponse{
+		Items: items,
+		Total: total,
+	}, nil
+}
+
+func (s *ServiceFacade) GetAggIBCChannelsVolume(filter filters.IBCChannelsAgg) (items []smodels.IBCChannelAggItem, err error) {
+	items, err = s.dao.GetAggIBCChannelsVolume(filter)
+	if err != nil {
+		return nil, fmt.Errorf("dao.GetAggIBCChannelsVolume: %s", err.Error())
+	}
+	for i, item := range items {
+		items[i].Denom = s.displayCurrency(item.Denom)
+	}
+	return items, nil
+}
@@ -12,27 +12,26 @@ import (
 )
 
 const (
-	precision = 6
-
 	DepositPeriodProposalStatus = "PROPOSAL_STATUS_DEPOSIT_PERIOD"
 	VotingPeriodProposalStatus  = "PROPOSAL_STATUS_VOTING_PERIOD"
 	PassedProposalStatus        = "PROPOSAL_STATUS_PASSED"
 	RejectedProposalStatus      = "PROPOSAL_STATUS_REJECTED"
 	FailedProposalStatus        = "PROPOSAL_STATUS_FAILED"
-)
 
-var PrecisionDiv = decimal.New(1, precision)
+	BondedValidatorStatus = "BOND_STATUS_BONDED"
+)
 
 type (
 	API struct {
 		cfg    config.Config
 		client *http.Client
 	}
+	Amount struct {
+		Denom  string          `json:"denom"`
+		Amount decimal.Decimal `json:"amount"`
+	}
 	CommunityPool struct {
-		Pool []struct {
-			Denom  string          `json:"denom"`
-			Amount decimal.Decimal `json:"amount"`
-		} `json:"pool"`
+		Pool []Amount `json:"pool"`
 	}
 	Validators struct {
 		Validators []Validator `json:"validators"`
@@ -43,6 +42,7 @@ type (
 			Type string `json:"@type"`
 			Key  string `json:"key"`
 		} `json:"consensus_pubkey"`
+		Status          string          `json:"status"`
 		Tokens          uint64          `json:"tokens,string"`
 		DelegatorShares decimal.Decimal `json:"delegator_shares"`
 		Description     struct {
@@ -66,10 +66,7 @@ type (
 		Inflation decimal.Decimal `json:"inflation"`
 	}
 	AmountResult struct {
-		Balances []struct {
-			Denom  string          `json:"denom"`
-			Amount decimal.Decimal `json:"amount"`
-		} `json:"balances"`
+		Balances []Amount `json:"balances"`
 	}
 	StakingPool struct {
 		Pool struct {
@@ -78,10 +75,7 @@ type (
 		} `json:"pool"`
 	}
 	Supply struct {
-		Amount struct {
-			Denom  string          `json:"denom"`
-			Amount decimal.Decimal `json:"amount"`
-		} `json:"amount"`
+		Amount Amount `json:"amount"`
 	}
 	StakeResult struct {
 		DelegationResponses []struct {
@@ -102,28 +96,28 @@ type (
 		} `json:"unbonding_responses"`
 	}
 	ProposalsResult struct {
-		Proposals []struct {
-			Content struct {
-				Type        string `json:"@type"`
-				Title       string `json:"title"`
-				Description string `json:"description"`
-			} `json:"content"`
-			ProposalID       uint64 `json:"proposal_id,string"`
-			Status           string `json:"status"`
-			FinalTallyResult struct {
-				Yes        int64 `json:"yes,string"`
-				Abstain    int64 `json:"abstain,string"`
-				No         int64 `json:"no,string"`
-				NoWithVeto int64 `json:"no_with_veto,string"`
-			} `json:"final_tally_result"`
-			SubmitTime     time.Time `json:"submit_time"`
-			DepositEndTime time.Time `json:"deposit_end_time"`
-			TotalDeposit   []struct {
-				Amount decimal.Decimal `json:"amount"`
-			} `json:"total_deposit"`
-			VotingStartTime time.Time `json:"voting_start_time"`
-			VotingEndTime   time.Time `json:"voting_end_time"`
-		} `json:"proposals"`
+		Proposals []Proposal `json:"proposals"`
+	}
+	Proposal struct {
+		Content struct {
+			Type        string `json:"@type"`
+			Title       string `json:"title"`
+			Description string `json:"description"`
+		} `json:"content"`
+		ProposalID       uint64    `json:"proposal_id,string"`
+		Status           string    `json:"status"`
+		FinalTallyResult Tally     `json:"final_tally_result"`
+		SubmitTime       time.Time `json:"submit_time"`
+		DepositEndTime   time.Time `json:"deposit_end_time"`
+		TotalDeposit     []Amount  `json:"total_deposit"`
+		VotingStartTime  time.Time `json:"voting_start_time"`
+		VotingEndTime    time.Time `json:"voting_end_time"`
+	}
+	Tally struct {
+		Yes        int64 `json:"yes,string"`
+		Abstain    int64 `json:"abstain,string"`
+		No         int64 `json:"no,string"`
+		NoWithVeto int64 `json:"no_with_veto,string"`
 	}
 	ProposalProposer struct {
 		Proposal struct {
@@ -138,10 +132,7 @@ type (
 				ValidatorAddress string          `json:"validator_address"`
 				Shares           decimal.Decimal `json:"shares"`
 			} `json:"delegation"`
-			Balance struct {
-				Denom  string          `json:"denom"`
-				Amount decimal.Decimal `json:"amount"`
-			} `json:"balance"`
+			Balance Amount `json:"balance"`
 		} `json:"delegation_response"`
 	}
 	ProposalVotersResult struct {
@@ -152,12 +143,20 @@ type (
 		} `json:"result"`
 	}
 	ProposalTallyResult struct {
-		Tally struct {
-			Yes        int64 `json:"yes,string"`
-			Abstain    int64 `json:"abstain,string"`
-			No         int64 `json:"no,string"`
-			NoWithVeto int64 `json:"no_with_veto,string"`
-		} `json:"tally"`
+		Tally Tally `json:"tally"`
+	}
+	DistributionParams struct {
+		Params struct {
+			CommunityTax        decimal.Decimal `json:"community_tax"`
+			BaseProposerReward  decimal.Decimal `json:"base_proposer_reward"`
+			BonusProposerReward decimal.Decimal `json:"bonus_proposer_reward"`
+		} `json:"params"`
+	}
+	SlashingParams struct {
+		Params struct {
+			SignedBlocksWindow uint64          `json:"signed_blocks_window,string"`
+			MinSignedPerWindow decimal.Decimal `json:"min_signed_per_window"`
+		} `json:"params"`
 	}
 )
 
@@ -196,11 +195,11 @@ func (api API) GetCommunityPoolAmount() (amount decimal.Decimal, err error) {
 		return amount, fmt.Errorf("request: %s", err.Error())
 	}
 	for _, p := range cp.Pool {
-		if p.Denom == "uatom" {
+		if p.Denom == api.cfg.Chain.BaseDenom {
 			amount = amount.Add(p.Amount)
 		}
 	}
-	return amount.Div(PrecisionDiv), nil
+	return amount.Div(api.cfg.Chain.PrecisionDiv()), nil
 }
 
 func (api API) GetValidators() (items []Validator, err error) {
@@ -223,11 +222,11 @@ func (api API) GetInflation() (amount decimal.Decimal, err error) {
 
 func (api API) GetTotalSupply() (amount decimal.Decimal, err error) {
 	var s Supply
-	err = api.request("cosmos/bank/v1beta1/supply/uatom", &s)
+	err = api.request(fmt.Sprintf("cosmos/bank/v1beta1/supply/%s", api.cfg.Chain.BaseDenom), &s)
 	if err != nil {
 		return amount, fmt.Errorf("request: %s", err.Error())
 	}
-	return s.Amount.Amount.Div(PrecisionDiv), nil
+	return s.Amount.Amount.Div(api.cfg.Chain.PrecisionDiv()), nil
 }
 
 func (api API) GetStakingPool() (sp StakingPool, err error) {
@@ -235,8 +234,8 @@ func (api API) GetStakingPool() (sp StakingPool, err error) {
 	if err != nil {
 		return sp, fmt.Errorf("request: %s", err.Error())
 	}
-	sp.Pool.BondedTokens = sp.Pool.BondedTokens.Div(PrecisionDiv)
-	sp.Pool.NotBondedTokens = sp.Pool.NotBondedTokens.Div(PrecisionDiv)
+	sp.Pool.BondedTokens = sp.Pool.BondedTokens.Div(api.cfg.Chain.PrecisionDiv())
+	sp.Pool.NotBondedTokens = sp.Pool.NotBondedTokens.Div(api.cfg.Chain.PrecisionDiv())
 	return sp, nil
 }
 
@@ -247,11 +246,11 @@ func (api API) GetBalance(address string) (amount decimal.Decimal, err error) {
 		return amount, fmt.Errorf("request: %s", err.Error())
 	}
 	for _, b := range result.Balances {
-		if b.Denom == "uatom" {
+		if b.Denom == api.cfg.Chain.BaseDenom {
 			amount = amount.Add(b.Amount)
 		}
 	}
-	return amount.Div(PrecisionDiv), nil
+	return amount.Div(api.cfg.Chain.PrecisionDiv()), nil
 }
 
 func (api API) GetStake(address string) (amount decimal.Decimal, err error) {
@@ -264,7 +263,7 @@ func (api API) GetStake(address string) (amount decimal.Decimal, err error) {
 	for _, r := range result.DelegationResponses {
 		shares = shares.Add(r.Delegation.Shares)
 	}
-	return shares.Div(PrecisionDiv), nil
+	return shares.Div(api.cfg.Chain.PrecisionDiv()), nil
 }
 
 func (api API) GetUnbonding(address string) (amount decimal.Decimal, err error) {
@@ -278,7 +277,7 @@ func (api API) GetUnbonding(address string) (amount decimal.Decimal, err error)
 			amount = amount.Add(entry.Balance)
 		}
 	}
-	amount = amount.Div(PrecisionDiv)
+	amount = amount.Div(api.cfg.Chain.PrecisionDiv())
 	return amount, nil
 }
 
@@ -296,7 +295,7 @@ func (api API) GetDelegatorValidatorStake(delegator string, validator string) (a
 	if err != nil {
 		return amount, fmt.Errorf("request: %s", err.Error())
 	}
-	return result.DelegationResponse.Delegation.Shares.Div(PrecisionDiv), nil
+	return result.DelegationResponse.Delegation.Shares.Div(api.cfg.Chain.PrecisionDiv()), nil
 }
 
 func (api API) ProposalTallyResult(id uint64) (result ProposalTallyResult, err error) {
@@ -306,3 +305,19 @@ func (api API) ProposalTallyResult(id uint64) (result ProposalTallyResult, err e
 	}
 	return result, nil
 }
+
+func (api API) GetSlashingParams() (params SlashingParams, err error) {
+	err = api.request("cosmos/slashing/v1beta1/params", &params)
+	if err != nil {
+		return params, fmt.Errorf("request: %s", err.Error())
+	}
+	return params, nil
+}
+
+func (api API) GetDistributionParams() (params DistributionParams, err error) {
+	err = api.request("cosmos/distribution/v1beta1/params", &params)
+	if err != nil {
+		return params, fmt.Errorf("request: %s", err.Error())
+	}
+	return params, nil
+}
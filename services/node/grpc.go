@@ -0,0 +1,348 @@
+package node
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	ibctypes "github.com/cosmos/cosmos-sdk/x/ibc/core/types"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+	paramsproposal "github.com/cosmos/cosmos-sdk/x/params/types/proposal"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	"github.com/kwanifi/numiscan-api/config"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc"
+)
+
+const (
+	grpcTimeout = time.Minute
+	grpcLimit   = 10000
+)
+
+// GRPCAPI implements the node queries with the generated query clients of the cosmos-sdk.
+type GRPCAPI struct {
+	cfg      config.Config
+	registry codectypes.InterfaceRegistry
+	bank     banktypes.QueryClient
+	staking  stakingtypes.QueryClient
+	gov      govtypes.QueryClient
+	distr    distrtypes.QueryClient
+	mint     minttypes.QueryClient
+	slashing slashingtypes.QueryClient
+}
+
+func NewGRPCAPI(cfg config.Config) (*GRPCAPI, error) {
+	conn, err := grpc.Dial(cfg.Parser.GRPC, grpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("grpc.Dial: %s", err.Error())
+	}
+	registry := codectypes.NewInterfaceRegistry()
+	govtypes.RegisterInterfaces(registry)
+	distrtypes.RegisterInterfaces(registry)
+	paramsproposal.RegisterInterfaces(registry)
+	upgradetypes.RegisterInterfaces(registry)
+	ibctypes.RegisterInterfaces(registry)
+	return &GRPCAPI{
+		cfg:      cfg,
+		registry: registry,
+		bank:     banktypes.NewQueryClient(conn),
+		staking:  stakingtypes.NewQueryClient(conn),
+		gov:      govtypes.NewQueryClient(conn),
+		distr:    distrtypes.NewQueryClient(conn),
+		mint:     minttypes.NewQueryClient(conn),
+		slashing: slashingtypes.NewQueryClient(conn),
+	}, nil
+}
+
+func (api GRPCAPI) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), grpcTimeout)
+}
+
+func (api GRPCAPI) GetCommunityPoolAmount() (amount decimal.Decimal, err error) {
+	ctx, cancel := api.context()
+	defer cancel()
+	resp, err := api.distr.CommunityPool(ctx, &distrtypes.QueryCommunityPoolRequest{})
+	if err != nil {
+		return amount, fmt.Errorf("distr.CommunityPool: %s", err.Error())
+	}
+	for _, coin := range resp.Pool {
+		if coin.Denom == api.cfg.Chain.BaseDenom {
+			amount = amount.Add(decFromSDK(coin.Amount))
+		}
+	}
+	return amount.Div(api.cfg.Chain.PrecisionDiv()), nil
+}
+
+func (api GRPCAPI) GetValidators() (items []Validator, err error) {
+	ctx, cancel := api.context()
+	defer cancel()
+	resp, err := api.staking.Validators(ctx, &stakingtypes.QueryValidatorsRequest{Pagination: &query.PageRequest{Limit: grpcLimit}})
+	if err != nil {
+		return nil, fmt.Errorf("staking.Validators: %s", err.Error())
+	}
+	for _, v := range resp.Validators {
+		var item Validator
+		item.OperatorAddress = v.OperatorAddress
+		if v.ConsensusPubkey != nil {
+			var pk ed25519.PubKey
+			err = pk.Unmarshal(v.ConsensusPubkey.Value)
+			if err != nil {
+				return nil, fmt.Errorf("validator %s: pk.Unmarshal: %s", v.OperatorAddress, err.Error())
+			}
+			item.ConsensusPubkey.Type = v.ConsensusPubkey.TypeUrl
+			item.ConsensusPubkey.Key = base64.StdEncoding.EncodeToString(pk.Key)
+		}
+		item.Status = v.Status.String()
+		item.Tokens, err = uint64FromSDK(v.Tokens)
+		if err != nil {
+			return nil, fmt.Errorf("validator %s: tokens: %s", v.OperatorAddress, err.Error())
+		}
+		item.DelegatorShares = decFromSDK(v.DelegatorShares)
+		item.Description.Moniker = v.Description.Moniker
+		item.Description.Identity = v.Description.Identity
+		item.Description.Website = v.Description.Website
+		item.Description.Details = v.Description.Details
+		item.UnbondingHeight = uint64(v.UnbondingHeight)
+		item.UnbondingTime = v.UnbondingTime
+		item.Commission.CommissionRates.Rate = decFromSDK(v.Commission.Rate)
+		item.Commission.CommissionRates.MaxRate = decFromSDK(v.Commission.MaxRate)
+		item.Commission.CommissionRates.MaxChangeRate = decFromSDK(v.Commission.MaxChangeRate)
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (api GRPCAPI) GetInflation() (amount decimal.Decimal, err error) {
+	ctx, cancel := api.context()
+	defer cancel()
+	resp, err := api.mint.Inflation(ctx, &minttypes.QueryInflationRequest{})
+	if err != nil {
+		return amount, fmt.Errorf("mint.Inflation: %s", err.Error())
+	}
+	return decFromSDK(resp.Inflation).Mul(decimal.New(100, 0)), nil
+}
+
+func (api GRPCAPI) GetTotalSupply() (amount decimal.Decimal, err error) {
+	ctx, cancel := api.context()
+	defer cancel()
+	resp, err := api.bank.SupplyOf(ctx, &banktypes.QuerySupplyOfRequest{Denom: api.cfg.Chain.BaseDenom})
+	if err != nil {
+		return amount, fmt.Errorf("bank.SupplyOf: %s", err.Error())
+	}
+	return intFromSDK(resp.Amount.Amount).Div(api.cfg.Chain.PrecisionDiv()), nil
+}
+
+func (api GRPCAPI) GetStakingPool() (sp StakingPool, err error) {
+	ctx, cancel := api.context()
+	defer cancel()
+	resp, err := api.staking.Pool(ctx, &stakingtypes.QueryPoolRequest{})
+	if err != nil {
+		return sp, fmt.Errorf("staking.Pool: %s", err.Error())
+	}
+	sp.Pool.BondedTokens = intFromSDK(resp.Pool.BondedTokens).Div(api.cfg.Chain.PrecisionDiv())
+	sp.Pool.NotBondedTokens = intFromSDK(resp.Pool.NotBondedTokens).Div(api.cfg.Chain.PrecisionDiv())
+	return sp, nil
+}
+
+func (api GRPCAPI) GetBalance(address string) (amount decimal.Decimal, err error) {
+	ctx, cancel := api.context()
+	defer cancel()
+	resp, err := api.bank.Balance(ctx, &banktypes.QueryBalanceRequest{Address: address, Denom: api.cfg.Chain.BaseDenom})
+	if err != nil {
+		return amount, fmt.Errorf("bank.Balance: %s", err.Error())
+	}
+	if resp.Balance == nil {
+		return decimal.Zero, nil
+	}
+	return intFromSDK(resp.Balance.Amount).Div(api.cfg.Chain.PrecisionDiv()), nil
+}
+
+func (api GRPCAPI) GetStake(address string) (amount decimal.Decimal, err error) {
+	ctx, cancel := api.context()
+	defer cancel()
+	resp, err := api.staking.DelegatorDelegations(ctx, &stakingtypes.QueryDelegatorDelegationsRequest{
+		DelegatorAddr: address,
+		Pagination:    &query.PageRequest{Limit: grpcLimit},
+	})
+	if err != nil {
+		return amount, fmt.Errorf("staking.DelegatorDelegations: %s", err.Error())
+	}
+	shares := decimal.Zero
+	for _, r := range resp.DelegationResponses {
+		shares = shares.Add(decFromSDK(r.Delegation.Shares))
+	}
+	return shares.Div(api.cfg.Chain.PrecisionDiv()), nil
+}
+
+func (api GRPCAPI) GetUnbonding(address string) (amount decimal.Decimal, err error) {
+	ctx, cancel := api.context()
+	defer cancel()
+	resp, err := api.staking.DelegatorUnbondingDelegations(ctx, &stakingtypes.QueryDelegatorUnbondingDelegationsRequest{
+		DelegatorAddr: address,
+		Pagination:    &query.PageRequest{Limit: grpcLimit},
+	})
+	if err != nil {
+		return amount, fmt.Errorf("staking.DelegatorUnbondingDelegations: %s", err.Error())
+	}
+	for _, r := range resp.UnbondingResponses {
+		for _, entry := range r.Entries {
+			amount = amount.Add(intFromSDK(entry.Balance))
+		}
+	}
+	return amount.Div(api.cfg.Chain.PrecisionDiv()), nil
+}
+
+func (api GRPCAPI) GetProposals() (proposals ProposalsResult, err error) {
+	ctx, cancel := api.context()
+	defer cancel()
+	resp, err := api.gov.Proposals(ctx, &govtypes.QueryProposalsRequest{Pagination: &query.PageRequest{Limit: grpcLimit}})
+	if err != nil {
+		return proposals, fmt.Errorf("gov.Proposals: %s", err.Error())
+	}
+	for _, p := range resp.Proposals {
+		var item Proposal
+		if p.Content != nil {
+			item.Content.Type = p.Content.TypeUrl
+			var content govtypes.Content
+			if err := api.registry.UnpackAny(p.Content, &content); err == nil {
+				item.Content.Title = content.GetTitle()
+				item.Content.Description = content.GetDescription()
+			}
+		}
+		item.ProposalID = p.ProposalId
+		item.Status = p.Status.String()
+		item.FinalTallyResult, err = tallyFromSDK(p.FinalTallyResult)
+		if err != nil {
+			return proposals, fmt.Errorf("proposal %d: tallyFromSDK: %s", p.ProposalId, err.Error())
+		}
+		item.SubmitTime = p.SubmitTime
+		item.DepositEndTime = p.DepositEndTime
+		for _, coin := range p.TotalDeposit {
+			item.TotalDeposit = append(item.TotalDeposit, Amount{Denom: coin.Denom, Amount: intFromSDK(coin.Amount)})
+		}
+		item.VotingStartTime = p.VotingStartTime
+		item.VotingEndTime = p.VotingEndTime
+		proposals.Proposals = append(proposals.Proposals, item)
+	}
+	return proposals, nil
+}
+
+func (api GRPCAPI) GetDelegatorValidatorStake(delegator string, validator string) (amount decimal.Decimal, err error) {
+	ctx, cancel := api.context()
+	defer cancel()
+	resp, err := api.staking.Delegation(ctx, &stakingtypes.QueryDelegationRequest{DelegatorAddr: delegator, ValidatorAddr: validator})
+	if err != nil {
+		return amount, fmt.Errorf("staking.Delegation: %s", err.Error())
+	}
+	if resp.DelegationResponse == nil {
+		return decimal.Zero, nil
+	}
+	return decFromSDK(resp.DelegationResponse.Delegation.Shares).Div(api.cfg.Chain.PrecisionDiv()), nil
+}
+
+func (api GRPCAPI) ProposalTallyResult(id uint64) (result ProposalTallyResult, err error) {
+	ctx, cancel := api.context()
+	defer cancel()
+	resp, err := api.gov.TallyResult(ctx, &govtypes.QueryTallyResultRequest{ProposalId: id})
+	if err != nil {
+		return result, fmt.Errorf("gov.TallyResult: %s", err.Error())
+	}
+	result.Tally, err = tallyFromSDK(resp.Tally)
+	if err != nil {
+		return result, fmt.Errorf("tallyFromSDK: %s", err.Error())
+	}
+	return result, nil
+}
+
+func (api GRPCAPI) GetSlashingParams() (params SlashingParams, err error) {
+	ctx, cancel := api.context()
+	defer cancel()
+	resp, err := api.slashing.Params(ctx, &slashingtypes.QueryParamsRequest{})
+	if err != nil {
+		return params, fmt.Errorf("slashing.Params: %s", err.Error())
+	}
+	params.Params.SignedBlocksWindow = uint64(resp.Params.SignedBlocksWindow)
+	params.Params.MinSignedPerWindow = decFromSDK(resp.Params.MinSignedPerWindow)
+	return params, nil
+}
+
+func (api GRPCAPI) GetDistributionParams() (params DistributionParams, err error) {
+	ctx, cancel := api.context()
+	defer cancel()
+	resp, err := api.distr.Params(ctx, &distrtypes.QueryParamsRequest{})
+	if err != nil {
+		return params, fmt.Errorf("distr.Params: %s", err.Error())
+	}
+	params.Params.CommunityTax = decFromSDK(resp.Params.CommunityTax)
+	params.Params.BaseProposerReward = decFromSDK(resp.Params.BaseProposerReward)
+	params.Params.BonusProposerReward = decFromSDK(resp.Params.BonusProposerReward)
+	return params, nil
+}
+
+func tallyFromSDK(tally govtypes.TallyResult) (result Tally, err error) {
+	result.Yes, err = int64FromSDK(tally.Yes)
+	if err != nil {
+		return result, fmt.Errorf("yes: %s", err.Error())
+	}
+	result.Abstain, err = int64FromSDK(tally.Abstain)
+	if err != nil {
+		return result, fmt.Errorf("abstain: %s", err.Error())
+	}
+	result.No, err = int64FromSDK(tally.No)
+	if err != nil {
+		return result, fmt.Errorf("no: %s", err.Error())
+	}
+	result.NoWithVeto, err = int64FromSDK(tally.NoWithVeto)
+	if err != nil {
+		return result, fmt.Errorf("no_with_veto: %s", err.Error())
+	}
+	return result, nil
+}
+
+// int64FromSDK returns an error instead of the panic of sdk.Int.Int64 when the value does not fit.
+func int64FromSDK(i sdk.Int) (int64, error) {
+	if i.IsNil() {
+		return 0, nil
+	}
+	if !i.IsInt64() {
+		return 0, fmt.Errorf("%s overflows int64", i.String())
+	}
+	return i.Int64(), nil
+}
+
+// uint64FromSDK returns an error instead of the panic of sdk.Int.Uint64 when the value does not fit.
+func uint64FromSDK(i sdk.Int) (uint64, error) {
+	if i.IsNil() {
+		return 0, nil
+	}
+	if !i.IsUint64() {
+		return 0, fmt.Errorf("%s overflows uint64", i.String())
+	}
+	return i.Uint64(), nil
+}
+
+func intFromSDK(i sdk.Int) decimal.Decimal {
+	if i.IsNil() {
+		return decimal.Zero
+	}
+	d, _ := decimal.NewFromString(i.String())
+	return d
+}
+
+func decFromSDK(d sdk.Dec) decimal.Decimal {
+	if d.IsNil() {
+		return decimal.Zero
+	}
+	r, _ := decimal.NewFromString(d.String())
+	return r
+}
@@ -1,12 +1,16 @@
 package hub3
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"github.com/shopspring/decimal"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/bytes"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -22,6 +26,13 @@ const (
 	DepositMsg                     = "/cosmos.gov.v1beta1.MsgDeposit"
 	VoteMsg                        = "/cosmos.gov.v1beta1.MsgVote"
 	UnJailMsg                      = "/cosmos.slashing.v1beta1.MsgUnjail"
+	CreateValidatorMsg             = "/cosmos.staking.v1beta1.MsgCreateValidator"
+	EditValidatorMsg               = "/cosmos.staking.v1beta1.MsgEditValidator"
+	IBCTransferMsg                 = "/ibc.applications.transfer.v1.MsgTransfer"
+	IBCRecvPacketMsg               = "/ibc.core.channel.v1.MsgRecvPacket"
+	IBCAcknowledgementMsg          = "/ibc.core.channel.v1.MsgAcknowledgement"
+	IBCTimeoutMsg                  = "/ibc.core.channel.v1.MsgTimeout"
+	IBCTimeoutOnCloseMsg           = "/ibc.core.channel.v1.MsgTimeoutOnClose"
 )
 
 type (
@@ -59,7 +70,7 @@ type (
 				Txs []string `json:"txs"`
 			} `json:"data"`
 			Evidence struct {
-				Evidence []interface{} `json:"evidence"`
+				Evidence []BlockEvidence `json:"evidence"`
 			} `json:"evidence"`
 			LastCommit struct {
 				Height  string `json:"height"`
@@ -78,6 +89,38 @@ type (
 		} `json:"block"`
 	}
 
+	// BlockEvidence keeps the fields of the duplicate vote evidence, other kinds of evidence are left empty.
+	BlockEvidence struct {
+		Type  string `json:"type"`
+		Value struct {
+			VoteA struct {
+				Height           uint64 `json:"height,string"`
+				ValidatorAddress string `json:"validator_address"`
+			} `json:"vote_a"`
+			ValidatorPower int64 `json:"ValidatorPower,string"`
+		} `json:"value"`
+	}
+
+	BlockResults struct {
+		BeginBlockEvents []BlockEvent `json:"begin_block_events"`
+		EndBlockEvents   []BlockEvent `json:"end_block_events"`
+	}
+	// BlockEvent is an ABCI event of the block, the attributes are base64 encoded by the RPC.
+	BlockEvent struct {
+		Type       string `json:"type"`
+		Attributes []struct {
+			Key   []byte `json:"key"`
+			Value []byte `json:"value"`
+		} `json:"attributes"`
+	}
+
+	SlashingParams struct {
+		Params struct {
+			SlashFractionDoubleSign decimal.Decimal `json:"slash_fraction_double_sign"`
+			SlashFractionDowntime   decimal.Decimal `json:"slash_fraction_downtime"`
+		} `json:"params"`
+	}
+
 	Tx struct {
 		Tx struct {
 			Body struct {
@@ -91,26 +134,19 @@ type (
 					Payer    string   `json:"payer"`
 					Granter  string   `json:"granter"`
 				} `json:"fee"`
-				Signatures []string `json:"signatures"`
+				SignerInfos []SignerInfo `json:"signer_infos"`
+				Signatures  []string     `json:"signatures"`
 			} `json:"auth_info"`
 		} `json:"tx"`
 		TxResponse struct {
-			Height uint64 `json:"height,string"`
-			Hash   string `json:"txhash"`
-			Data   string `json:"data"`
-			RawLog string `json:"raw_log"`
-			Code   int64  `json:"code"`
-			Logs   []struct {
-				Events []struct {
-					Type       string `json:"type"`
-					Attributes []struct {
-						Key   string `json:"key"`
-						Value string `json:"value"`
-					} `json:"attributes"`
-				} `json:"events"`
-			} `json:"logs"`
-			GasWanted uint64 `json:"gas_wanted,string"`
-			GasUsed   uint64 `json:"gas_used,string"`
+			Height    uint64  `json:"height,string"`
+			Hash      string  `json:"txhash"`
+			Data      string  `json:"data"`
+			RawLog    string  `json:"raw_log"`
+			Code      int64   `json:"code"`
+			Logs      []TxLog `json:"logs"`
+			GasWanted uint64  `json:"gas_wanted,string"`
+			GasUsed   uint64  `json:"gas_used,string"`
 			Tx        struct {
 				Type string `json:"@type"`
 				Body struct {
@@ -122,6 +158,25 @@ type (
 		} `json:"tx_response"`
 	}
 
+	SignerInfo struct {
+		PublicKey struct {
+			Type string `json:"@type"`
+			Key  string `json:"key"`
+		} `json:"public_key"`
+	}
+
+	TxLog struct {
+		MsgIndex int       `json:"msg_index"`
+		Events   []TxEvent `json:"events"`
+	}
+	TxEvent struct {
+		Type       string `json:"type"`
+		Attributes []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"attributes"`
+	}
+
 	BaseMsg struct {
 		Type string `json:"@type"`
 	}
@@ -197,6 +252,75 @@ type (
 	MsgUnjail struct {
 		ValidatorAddr string `json:"validator_addr"`
 	}
+	ValidatorDescription struct {
+		Moniker  string `json:"moniker"`
+		Identity string `json:"identity"`
+		Website  string `json:"website"`
+		Details  string `json:"details"`
+	}
+	ValidatorCommissionRates struct {
+		Rate          decimal.Decimal `json:"rate"`
+		MaxRate       decimal.Decimal `json:"max_rate"`
+		MaxChangeRate decimal.Decimal `json:"max_change_rate"`
+	}
+	ValidatorPubKey struct {
+		Type string `json:"@type"`
+		Key  string `json:"key"`
+	}
+	MsgCreateValidator struct {
+		Description       ValidatorDescription     `json:"description"`
+		Commission        ValidatorCommissionRates `json:"commission"`
+		MinSelfDelegation decimal.Decimal          `json:"min_self_delegation"`
+		DelegatorAddress  string                   `json:"delegator_address"`
+		ValidatorAddress  string                   `json:"validator_address"`
+		Pubkey            ValidatorPubKey          `json:"pubkey"`
+		Value             Amount                   `json:"value"`
+	}
+	// MsgEditValidator leaves the fields equal to `[do-not-modify]` and the empty rates unchanged.
+	MsgEditValidator struct {
+		Description       ValidatorDescription `json:"description"`
+		ValidatorAddress  string               `json:"validator_address"`
+		CommissionRate    string               `json:"commission_rate"`
+		MinSelfDelegation string               `json:"min_self_delegation"`
+	}
+	MsgTransfer struct {
+		SourcePort    string `json:"source_port"`
+		SourceChannel string `json:"source_channel"`
+		Token         Amount `json:"token"`
+		Sender        string `json:"sender"`
+		Receiver      string `json:"receiver"`
+	}
+	MsgRecvPacket struct {
+		Packet Packet `json:"packet"`
+		Signer string `json:"signer"`
+	}
+	MsgAcknowledgement struct {
+		Packet          Packet `json:"packet"`
+		Acknowledgement []byte `json:"acknowledgement"`
+		Signer          string `json:"signer"`
+	}
+	MsgTimeout struct {
+		Packet Packet `json:"packet"`
+		Signer string `json:"signer"`
+	}
+	Packet struct {
+		Sequence           uint64 `json:"sequence,string"`
+		SourcePort         string `json:"source_port"`
+		SourceChannel      string `json:"source_channel"`
+		DestinationPort    string `json:"destination_port"`
+		DestinationChannel string `json:"destination_channel"`
+		Data               []byte `json:"data"`
+	}
+	FungibleTokenPacketData struct {
+		Denom    string          `json:"denom"`
+		Amount   decimal.Decimal `json:"amount"`
+		Sender   string          `json:"sender"`
+		Receiver string          `json:"receiver"`
+	}
+	Acknowledgement struct {
+		Result []byte `json:"result"`
+		Error  string `json:"error"`
+	}
 
 	TxsFilter struct {
 		Limit     uint64
@@ -207,14 +331,15 @@ type (
 	}
 
 	Validatorsets struct {
-		Validators []struct {
-			Address string `json:"address"`
-			PubKey  struct {
-				Type string `json:"@type"`
-				Key  string `json:"key"`
-			} `json:"pub_key"`
-			VotingPower decimal.Decimal `json:"voting_power"`
-		} `json:"validators"`
+		Validators []ValidatorsetItem `json:"validators"`
+	}
+	ValidatorsetItem struct {
+		Address string `json:"address"`
+		PubKey  struct {
+			Type string `json:"@type"`
+			Key  string `json:"key"`
+		} `json:"pub_key"`
+		VotingPower decimal.Decimal `json:"voting_power"`
 	}
 )
 
@@ -244,6 +369,38 @@ func (api *API) GetTx(hash string) (tx Tx, err error) {
 	return tx, err
 }
 
+// GetBlockTxs returns the transactions of the block, one request per transaction.
+func (api *API) GetBlockTxs(block Block) (txs []Tx, err error) {
+	for _, hash := range blockTxHashes(block) {
+		tx, err := api.GetTx(hash)
+		if err != nil {
+			return nil, fmt.Errorf("GetTx: %s", err.Error())
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+// blockTxHashes returns the hashes of the block transactions in the order of the block.
+func blockTxHashes(block Block) (hashes []string) {
+	for _, txData := range block.Block.Data.Txs {
+		decodedTx, _ := base64.StdEncoding.DecodeString(txData)
+		hashes = append(hashes, bytes.HexBytes(crypto.Sha256(decodedTx)).String())
+	}
+	return hashes
+}
+
+func (api *API) GetDenomTrace(hash string) (trace DenomTrace, err error) {
+	endpoint := fmt.Sprintf("ibc/applications/transfer/v1beta1/denom_traces/%s", hash)
+	err = api.get(endpoint, nil, &trace)
+	return trace, err
+}
+
+func (api *API) GetSlashingParams() (params SlashingParams, err error) {
+	err = api.get("cosmos/slashing/v1beta1/params", nil, &params)
+	return params, err
+}
+
 func (api *API) get(endpoint string, params map[string]string, result interface{}) error {
 	fullURL := fmt.Sprintf("%s/%s", api.address, endpoint)
 	if len(params) != 0 {
@@ -277,7 +434,29 @@ func (api *API) get(endpoint string, params map[string]string, result interface{
 	return nil
 }
 
+// GetValidatorset pages the set by offset. The node of v0.42 does not return the next key nor the total
+// for the validator sets, so a page past the end of a full last page ends the set as well.
 func (api *API) GetValidatorset(height uint64) (set Validatorsets, err error) {
-	err = api.get(fmt.Sprintf("cosmos/base/tendermint/v1beta1/validatorsets/%d", height), nil, &set)
-	return set, err
+	for {
+		var page struct {
+			Validatorsets
+			Pagination struct {
+				Total uint64 `json:"total,string"`
+			} `json:"pagination"`
+		}
+		err = api.get(fmt.Sprintf("cosmos/base/tendermint/v1beta1/validatorsets/%d", height), map[string]string{
+			"pagination.offset": strconv.Itoa(len(set.Validators)),
+			"pagination.limit":  strconv.Itoa(rpcPerPage),
+		}, &page)
+		if err != nil {
+			if len(set.Validators) != 0 && pageOutOfRange(err) {
+				return set, nil
+			}
+			return set, err
+		}
+		set.Validators = append(set.Validators, page.Validators...)
+		if lastValidatorsPage(len(page.Validators), len(set.Validators), page.Pagination.Total) {
+			return set, nil
+		}
+	}
 }
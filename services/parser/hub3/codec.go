@@ -0,0 +1,128 @@
+package hub3
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/std"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	crisistypes "github.com/cosmos/cosmos-sdk/x/crisis/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	evidencetypes "github.com/cosmos/cosmos-sdk/x/evidence/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	ibctransfertypes "github.com/cosmos/cosmos-sdk/x/ibc/applications/transfer/types"
+	ibctypes "github.com/cosmos/cosmos-sdk/x/ibc/core/types"
+	paramsproposal "github.com/cosmos/cosmos-sdk/x/params/types/proposal"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	secp256k1PubKeyType = "/cosmos.crypto.secp256k1.PubKey"
+	ed25519PubKeyType   = "/cosmos.crypto.ed25519.PubKey"
+)
+
+// txCodec decodes raw transactions into the json shape returned by the LCD,
+// so the parser handles transactions of every source the same way.
+type txCodec struct {
+	cdc *codec.ProtoCodec
+}
+
+func newTxCodec() *txCodec {
+	registry := codectypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(registry)
+	authtypes.RegisterInterfaces(registry)
+	vestingtypes.RegisterInterfaces(registry)
+	banktypes.RegisterInterfaces(registry)
+	crisistypes.RegisterInterfaces(registry)
+	distrtypes.RegisterInterfaces(registry)
+	evidencetypes.RegisterInterfaces(registry)
+	govtypes.RegisterInterfaces(registry)
+	paramsproposal.RegisterInterfaces(registry)
+	slashingtypes.RegisterInterfaces(registry)
+	stakingtypes.RegisterInterfaces(registry)
+	upgradetypes.RegisterInterfaces(registry)
+	ibctransfertypes.RegisterInterfaces(registry)
+	ibctypes.RegisterInterfaces(registry)
+	return &txCodec{cdc: codec.NewProtoCodec(registry)}
+}
+
+// decode fills the `tx` part of Tx from the protobuf encoded transaction.
+// Messages of unknown types keep only their `@type`.
+func (c *txCodec) decode(raw []byte, tx *Tx) error {
+	var txRaw txtypes.TxRaw
+	err := txRaw.Unmarshal(raw)
+	if err != nil {
+		return fmt.Errorf("TxRaw: %s", err.Error())
+	}
+	// messages are not unpacked here, so unknown types do not fail the whole transaction
+	var body txtypes.TxBody
+	err = body.Unmarshal(txRaw.BodyBytes)
+	if err != nil {
+		return fmt.Errorf("TxBody: %s", err.Error())
+	}
+	var authInfo txtypes.AuthInfo
+	err = authInfo.Unmarshal(txRaw.AuthInfoBytes)
+	if err != nil {
+		return fmt.Errorf("AuthInfo: %s", err.Error())
+	}
+	return c.fill(&body, &authInfo, txRaw.Signatures, tx)
+}
+
+// fill sets the `tx` part of Tx from the decoded transaction parts.
+func (c *txCodec) fill(body *txtypes.TxBody, authInfo *txtypes.AuthInfo, signatures [][]byte, tx *Tx) error {
+	tx.Tx.Body.Memo = body.Memo
+	tx.Tx.Body.Messages = make([]json.RawMessage, len(body.Messages))
+	for i, msg := range body.Messages {
+		data, err := c.cdc.MarshalJSON(msg)
+		if err != nil {
+			data, _ = json.Marshal(BaseMsg{Type: msg.TypeUrl})
+		}
+		tx.Tx.Body.Messages[i] = data
+	}
+	if authInfo != nil && authInfo.Fee != nil {
+		for _, coin := range authInfo.Fee.Amount {
+			amount, err := decimal.NewFromString(coin.Amount.String())
+			if err != nil {
+				return fmt.Errorf("decimal.NewFromString: %s", err.Error())
+			}
+			tx.Tx.AuthInfo.Fee.Amount = append(tx.Tx.AuthInfo.Fee.Amount, Amount{Denom: coin.Denom, Amount: amount})
+		}
+		tx.Tx.AuthInfo.Fee.GasLimit = authInfo.Fee.GasLimit
+		tx.Tx.AuthInfo.Fee.Payer = authInfo.Fee.Payer
+		tx.Tx.AuthInfo.Fee.Granter = authInfo.Fee.Granter
+	}
+	if authInfo != nil {
+		tx.Tx.AuthInfo.SignerInfos = make([]SignerInfo, len(authInfo.SignerInfos))
+		for i, info := range authInfo.SignerInfos {
+			if info.PublicKey == nil {
+				continue
+			}
+			tx.Tx.AuthInfo.SignerInfos[i].PublicKey.Type = info.PublicKey.TypeUrl
+			// secp256k1 and ed25519 keys share the encoding, the key of a multisig is left empty
+			if info.PublicKey.TypeUrl == secp256k1PubKeyType || info.PublicKey.TypeUrl == ed25519PubKeyType {
+				var pk secp256k1.PubKey
+				err := pk.Unmarshal(info.PublicKey.Value)
+				if err != nil {
+					return fmt.Errorf("PubKey: %s", err.Error())
+				}
+				tx.Tx.AuthInfo.SignerInfos[i].PublicKey.Key = base64.StdEncoding.EncodeToString(pk.Key)
+			}
+		}
+	}
+	for _, signature := range signatures {
+		tx.Tx.AuthInfo.Signatures = append(tx.Tx.AuthInfo.Signatures, base64.StdEncoding.EncodeToString(signature))
+	}
+	tx.TxResponse.Tx.Body.Messages = tx.Tx.Body.Messages
+	tx.TxResponse.Tx.Body.Memo = tx.Tx.Body.Memo
+	return nil
+}
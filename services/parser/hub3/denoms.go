@@ -0,0 +1,212 @@
+package hub3
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/kwanifi/numiscan-api/config"
+	"github.com/kwanifi/numiscan-api/services/helpers"
+	"github.com/shopspring/decimal"
+)
+
+const ibcDenomPrefix = "ibc/"
+
+var coinRegexp = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)([a-zA-Z][a-zA-Z0-9/:._-]{1,127})$`)
+
+type (
+	// Denom describes an on-chain denom and the currency its coins are stored under.
+	// The staking denom is stored by its display name as the rows before, the other denoms by their trace,
+	// so the stored rows do not depend on the labels of the config.
+	Denom struct {
+		Denom     string
+		Display   string
+		Currency  string
+		Exponent  int32
+		Path      string
+		BaseDenom string
+	}
+	DenomTrace struct {
+		DenomTrace struct {
+			Path      string `json:"path"`
+			BaseDenom string `json:"base_denom"`
+		} `json:"denom_trace"`
+	}
+	denomResolver interface {
+		GetDenomTrace(hash string) (trace DenomTrace, err error)
+	}
+	// denoms is a registry of known denoms. IBC vouchers are resolved
+	// through the denom traces of the node and cached.
+	denoms struct {
+		mu       sync.RWMutex
+		resolver denomResolver
+		base     string
+		known    map[string]Denom
+		items    map[string]Denom
+	}
+	coin struct {
+		Currency string
+		Amount   decimal.Decimal
+	}
+)
+
+func newDenoms(resolver denomResolver, chain config.Chain, known []config.Denom) *denoms {
+	r := &denoms{
+		resolver: resolver,
+		base:     chain.BaseDenom,
+		known:    make(map[string]Denom),
+		items:    make(map[string]Denom),
+	}
+	for _, d := range known {
+		r.known[d.Denom] = Denom{Denom: d.Denom, Display: d.Display, Currency: d.Denom, Exponent: d.Exponent, BaseDenom: d.Denom}
+	}
+	r.known[chain.BaseDenom] = Denom{
+		Denom:     chain.BaseDenom,
+		Display:   chain.DisplayDenom,
+		Currency:  chain.DisplayDenom,
+		Exponent:  chain.Exponent,
+		BaseDenom: chain.BaseDenom,
+	}
+	return r
+}
+
+// baseCurrency returns the currency of the staking denom.
+func (r *denoms) baseCurrency() string {
+	return r.known[r.base].Currency
+}
+
+func (r *denoms) get(denom string) (Denom, error) {
+	r.mu.RLock()
+	item, ok := r.items[denom]
+	r.mu.RUnlock()
+	if ok {
+		return item, nil
+	}
+	if strings.HasPrefix(denom, ibcDenomPrefix) {
+		trace, err := r.resolver.GetDenomTrace(strings.TrimPrefix(denom, ibcDenomPrefix))
+		if err != nil {
+			return item, fmt.Errorf("resolver.GetDenomTrace: %s", err.Error())
+		}
+		item = r.fromIBC(trace.DenomTrace.Path, trace.DenomTrace.BaseDenom)
+	} else {
+		item = r.fromBase(denom)
+	}
+	item.Denom = denom
+	r.mu.Lock()
+	r.items[denom] = item
+	r.mu.Unlock()
+	return item, nil
+}
+
+// getByTrace resolves a denom trace like `transfer/channel-0/uatom` without calling the node.
+func (r *denoms) getByTrace(trace string) Denom {
+	path, base := helpers.SplitDenomTrace(trace)
+	if path == "" {
+		item := r.fromBase(base)
+		item.Denom = base
+		return item
+	}
+	item := r.fromIBC(path, base)
+	item.Denom = fmt.Sprintf("%s%X", ibcDenomPrefix, sha256.Sum256([]byte(trace)))
+	return item
+}
+
+// fromIBC describes a voucher of the base denom received over the path. It is stored by the trace,
+// so the vouchers are not merged with the native denom nor with the vouchers of the other channels.
+func (r *denoms) fromIBC(path string, base string) Denom {
+	item := r.fromBase(base)
+	item.Path = path
+	item.Currency = path + "/" + base
+	return item
+}
+
+func (r *denoms) fromBase(base string) Denom {
+	item, ok := r.known[base]
+	if !ok {
+		item = Denom{Display: base, Currency: base, BaseDenom: base}
+	}
+	return item
+}
+
+func (r *denoms) convert(item Amount) (coin, error) {
+	denom, err := r.get(item.Denom)
+	if err != nil {
+		return coin{}, err
+	}
+	return denom.coin(item.Amount), nil
+}
+
+// convertCoins converts raw coins to display units, merging equal currencies.
+func (r *denoms) convertCoins(items []Amount) (coins []coin, err error) {
+	index := make(map[string]int)
+	for _, item := range items {
+		if item.Denom == "" && item.Amount.IsZero() { // example height=1245781
+			continue
+		}
+		if item.Denom == "" {
+			return nil, fmt.Errorf("empty denom")
+		}
+		c, err := r.convert(item)
+		if err != nil {
+			return nil, err
+		}
+		if i, ok := index[c.Currency]; ok {
+			coins[i].Amount = coins[i].Amount.Add(c.Amount)
+			continue
+		}
+		index[c.Currency] = len(coins)
+		coins = append(coins, c)
+	}
+	return coins, nil
+}
+
+// baseAmount returns the amount of the staking denom, other denoms are ignored.
+func (r *denoms) baseAmount(items []Amount) (decimal.Decimal, error) {
+	coins, err := r.convertCoins(items)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	for _, c := range coins {
+		if c.Currency == r.baseCurrency() {
+			return c.Amount, nil
+		}
+	}
+	return decimal.Zero, nil
+}
+
+// coinID keeps the legacy id for the staking denom so already stored rows are replaced.
+func (r *denoms) coinID(id string, c coin) string {
+	if c.Currency == r.baseCurrency() {
+		return id
+	}
+	return makeHash(fmt.Sprintf("%s.%s", id, c.Currency))
+}
+
+func (d Denom) coin(amount decimal.Decimal) coin {
+	return coin{
+		Currency: d.Currency,
+		Amount:   amount.Div(decimal.New(1, d.Exponent)),
+	}
+}
+
+// parseCoins parses coins from events, like `10uatom,5ibc/27394FB...`.
+func parseCoins(str string) (items []Amount, err error) {
+	str = strings.TrimSpace(str)
+	if str == "" {
+		return nil, nil
+	}
+	for _, part := range strings.Split(str, ",") {
+		matches := coinRegexp.FindStringSubmatch(strings.TrimSpace(part))
+		if matches == nil {
+			return nil, fmt.Errorf("invalid coin: %s", part)
+		}
+		amount, err := decimal.NewFromString(matches[1])
+		if err != nil {
+			return nil, fmt.Errorf("decimal.NewFromString: %s", err.Error())
+		}
+		items = append(items, Amount{Denom: matches[2], Amount: amount})
+	}
+	return items, nil
+}
@@ -0,0 +1,91 @@
+package hub3
+
+import (
+	"testing"
+
+	"github.com/kwanifi/numiscan-api/config"
+	"github.com/shopspring/decimal"
+)
+
+func TestParseCoins(t *testing.T) {
+	items, err := parseCoins("10uatom,5.5ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(items) != 2 {
+		t.Error("wrong number of coins", items)
+		return
+	}
+	if items[0].Denom != "uatom" || !items[0].Amount.Equal(decimal.NewFromInt(10)) {
+		t.Error("wrong first coin", items[0])
+	}
+	if items[1].Denom != "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2" || !items[1].Amount.Equal(decimal.NewFromFloat(5.5)) {
+		t.Error("wrong second coin", items[1])
+	}
+	items, err = parseCoins("")
+	if err != nil || len(items) != 0 {
+		t.Error("empty string should give no coins", items, err)
+	}
+	_, err = parseCoins("uatom10")
+	if err == nil {
+		t.Error("expected error for invalid coin")
+	}
+}
+
+func TestDenomsGetByTrace(t *testing.T) {
+	r := newDenoms(nil, config.DefaultChain, nil)
+	d := r.getByTrace("transfer/channel-0/uatom")
+	if d.Path != "transfer/channel-0" || d.BaseDenom != "uatom" || d.Exponent != 6 || d.Currency != "transfer/channel-0/uatom" {
+		t.Error("wrong denom", d)
+	}
+	if d.Denom != "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2" {
+		t.Error("wrong ibc hash", d.Denom)
+	}
+	if d = r.getByTrace("transfer/channel-1/transfer/channel-0/uatom"); d.Currency != "transfer/channel-1/transfer/channel-0/uatom" {
+		t.Error("wrong currency of a multi-hop voucher", d.Currency)
+	}
+	d = r.getByTrace("gamm/pool/1")
+	if d.Path != "" || d.Currency != "gamm/pool/1" || d.Exponent != 0 {
+		t.Error("wrong denom", d)
+	}
+	c := r.getByTrace("uatom").coin(decimal.NewFromInt(1500000))
+	if c.Currency != r.baseCurrency() || !c.Amount.Equal(decimal.NewFromFloat(1.5)) {
+		t.Error("wrong coin", c)
+	}
+}
+
+type testResolver map[string]DenomTrace
+
+func (r testResolver) GetDenomTrace(hash string) (trace DenomTrace, err error) {
+	return r[hash], nil
+}
+
+func TestConvertCoinsKeepsVouchers(t *testing.T) {
+	resolver := testResolver{}
+	for hash, path := range map[string]string{"A": "transfer/channel-0", "B": "transfer/channel-1"} {
+		var trace DenomTrace
+		trace.DenomTrace.Path = path
+		trace.DenomTrace.BaseDenom = "uatom"
+		resolver[hash] = trace
+	}
+	r := newDenoms(resolver, config.DefaultChain, nil)
+	coins, err := r.convertCoins([]Amount{
+		{Denom: "uatom", Amount: decimal.NewFromInt(1000000)},
+		{Denom: "ibc/A", Amount: decimal.NewFromInt(2000000)},
+		{Denom: "ibc/B", Amount: decimal.NewFromInt(3000000)},
+		{Denom: "uatom", Amount: decimal.NewFromInt(1000000)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(coins) != 3 {
+		t.Fatal("vouchers should not be merged", coins)
+	}
+	if coins[0].Currency != "atom" || !coins[0].Amount.Equal(decimal.NewFromInt(2)) {
+		t.Error("wrong native coin", coins[0])
+	}
+	if coins[1].Currency != "transfer/channel-0/uatom" || coins[2].Currency != "transfer/channel-1/uatom" {
+		t.Error("wrong voucher currencies", coins[1], coins[2])
+	}
+}
@@ -1,80 +1,139 @@
 package hub3
 
 import (
+	"compress/gzip"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
+	"github.com/kwanifi/numiscan-api/config"
 	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/kwanifi/numiscan-api/log"
+	"github.com/kwanifi/numiscan-api/services/helpers"
 	"github.com/shopspring/decimal"
 )
 
-const genesisJson = "https://raw.githubusercontent.com/cosmos/launch/master/genesis.json"
 const saveGenesisBatch = 100
 
-type Genesis struct {
-	AppState struct {
-		Accounts []struct {
-			Address string   `json:"address"`
-			Coins   []Amount `json:"coins"`
-		} `json:"accounts"`
-		Distribution struct {
-			DelegatorStartingInfos []struct {
-				StartingInfo struct {
-					DelegatorAddress string `json:"delegator_address"`
-					StartingInfo     struct {
-						Stake decimal.Decimal `json:"stake"`
-					} `json:"starting_info"`
-					ValidatorAddress string `json:"validator_address"`
-				} `json:"starting_info"`
-			} `json:"delegator_starting_infos"`
-		} `json:"distribution"`
-		Staking struct {
-			Delegations []struct {
-				DelegatorAddress string          `json:"delegator_address"`
-				Shares           decimal.Decimal `json:"shares"`
-				ValidatorAddress string          `json:"validator_address"`
-			} `json:"delegations"`
-			Redelegations []struct {
-				DelegatorAddress string `json:"delegator_address"`
-				Entries          []struct {
-					SharesDst decimal.Decimal `json:"shares_dst"`
-				} `json:"entries"`
-				ValidatorDstAddress string `json:"validator_dst_address"`
-				ValidatorSrcAddress string `json:"validator_src_address"`
-			} `json:"redelegations"`
-		} `json:"staking"`
-	} `json:"app_state"`
-	GenesisTime time.Time `json:"genesis_time"`
-	Validators  []struct {
-		Address string          `json:"address"`
-		Name    string          `json:"name"`
-		Power   decimal.Decimal `json:"power"`
-	} `json:"validators"`
-}
-
-func GetGenesisState() (state Genesis, err error) {
-	resp, err := http.Get(genesisJson)
-	if err != nil {
-		return state, fmt.Errorf("http.Get: %s", err.Error())
-	}
-	data, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return state, fmt.Errorf("ioutil.ReadAll: %s", err.Error())
-	}
-	err = json.Unmarshal(data, &state)
-	if err != nil {
-		return state, fmt.Errorf("json.Unmarshal: %s", err.Error())
-	}
-
-	return state, nil
-}
-
-func ShowGenesisStructure() {
-	resp, _ := http.Get(genesisJson)
-	data, _ := ioutil.ReadAll(resp.Body)
+// errGenesisTimeRead stops the first pass over the genesis once the time is read.
+var errGenesisTimeRead = errors.New("genesis time is read")
+
+type (
+	GenesisAccount struct {
+		Address string   `json:"address"`
+		Coins   []Amount `json:"coins"`
+	}
+	GenesisDelegation struct {
+		DelegatorAddress string          `json:"delegator_address"`
+		Shares           decimal.Decimal `json:"shares"`
+		ValidatorAddress string          `json:"validator_address"`
+	}
+	GenesisValidator struct {
+		OperatorAddress string               `json:"operator_address"`
+		ConsensusPubkey ValidatorPubKey      `json:"consensus_pubkey"`
+		Description     ValidatorDescription `json:"description"`
+		Commission      struct {
+			CommissionRates ValidatorCommissionRates `json:"commission_rates"`
+		} `json:"commission"`
+		MinSelfDelegation decimal.Decimal `json:"min_self_delegation"`
+	}
+	GenesisRedelegation struct {
+		DelegatorAddress string `json:"delegator_address"`
+		Entries          []struct {
+			SharesDst decimal.Decimal `json:"shares_dst"`
+		} `json:"entries"`
+		ValidatorDstAddress string `json:"validator_dst_address"`
+		ValidatorSrcAddress string `json:"validator_src_address"`
+	}
+	// GenesisTx is a gentx of `genutil.gen_txs`, the validators of a new chain are created by them.
+	GenesisTx struct {
+		Body struct {
+			Messages []json.RawMessage `json:"messages"`
+		} `json:"body"`
+	}
+
+	// genesisReader walks a genesis document token by token, so only the
+	// values we are interested in are decoded.
+	genesisReader struct {
+		dec *json.Decoder
+	}
+
+	// genesisState accumulates the parsed genesis. Delegations are saved in batches,
+	// the genesis time is read by a first pass, accounts are saved at the end
+	// because their stake is spread over the whole document.
+	genesisState struct {
+		p           *Parser
+		time        time.Time
+		balances    map[string]decimal.Decimal
+		stakes      map[string]decimal.Decimal
+		delegations []dmodels.Delegation
+		validators  []validatorEvent
+		operators   map[string]bool
+	}
+)
+
+// openGenesis opens the genesis source configured for the chain.
+func openGenesis(cfg config.Config) (io.ReadCloser, error) {
+	source := cfg.Chain.Genesis
+	if source == config.GenesisFromNode {
+		if cfg.Parser.RPC == "" {
+			return nil, fmt.Errorf("parser.rpc is required to load the genesis from the node")
+		}
+		source = fmt.Sprintf("%s/genesis", strings.TrimSuffix(cfg.Parser.RPC, "/"))
+	}
+	var body io.ReadCloser
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		resp, err := http.Get(source)
+		if err != nil {
+			return nil, fmt.Errorf("http.Get: %s", err.Error())
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("bad status: %d", resp.StatusCode)
+		}
+		body = resp.Body
+	} else {
+		file, err := os.Open(source)
+		if err != nil {
+			return nil, fmt.Errorf("os.Open: %s", err.Error())
+		}
+		body = file
+	}
+	if !strings.HasSuffix(source, ".gz") {
+		return body, nil
+	}
+	gz, err := gzip.NewReader(body)
+	if err != nil {
+		body.Close()
+		return nil, fmt.Errorf("gzip.NewReader: %s", err.Error())
+	}
+	return gzipReadCloser{Reader: gz, body: body}, nil
+}
+
+type gzipReadCloser struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (r gzipReadCloser) Close() error {
+	r.Reader.Close()
+	return r.body.Close()
+}
+
+func ShowGenesisStructure(cfg config.Config) {
+	body, err := openGenesis(cfg)
+	if err != nil {
+		log.Error("ShowGenesisStructure: openGenesis: %s", err.Error())
+		return
+	}
+	defer body.Close()
+	data, _ := ioutil.ReadAll(body)
 	var value interface{}
 	_ = json.Unmarshal(data, &value)
 	printStruct(value, 0)
@@ -98,78 +157,405 @@ func printStruct(field interface{}, i int) {
 }
 
 func (p *Parser) parseGenesisState() error {
-	state, err := GetGenesisState()
+	genesisTime, err := p.readGenesisTime()
 	if err != nil {
-		return fmt.Errorf("getGenesisState: %s", err.Error())
+		return fmt.Errorf("readGenesisTime: %s", err.Error())
 	}
-	t, err := time.Parse("2006-01-02", "2019-12-11")
+	body, err := openGenesis(p.cfg)
 	if err != nil {
-		return fmt.Errorf("time.Parse: %s", err.Error())
+		return fmt.Errorf("openGenesis: %s", err.Error())
 	}
-	var (
-		delegations []dmodels.Delegation
-		accounts    []dmodels.Account
-	)
-	for i, delegation := range state.AppState.Staking.Delegations {
-		delegations = append(delegations, dmodels.Delegation{
-			ID:        makeHash(fmt.Sprintf("delegations.%d", i)),
-			TxHash:    "genesis",
-			Delegator: delegation.DelegatorAddress,
-			Validator: delegation.ValidatorAddress,
-			Amount:    delegation.Shares.Div(precisionDiv),
-			CreatedAt: t,
-		})
+	defer body.Close()
+
+	state := &genesisState{
+		p:         p,
+		time:      genesisTime,
+		balances:  make(map[string]decimal.Decimal),
+		stakes:    make(map[string]decimal.Decimal),
+		operators: make(map[string]bool),
 	}
-	for i, delegation := range state.AppState.Staking.Redelegations {
-		amount := decimal.Zero
-		for _, entry := range delegation.Entries {
-			amount = amount.Add(entry.SharesDst)
-		}
-		// ignore undelegation
-		delegations = append(delegations, dmodels.Delegation{
-			ID:        makeHash(fmt.Sprintf("redelegations.%d", i)),
-			TxHash:    "genesis",
-			Delegator: delegation.DelegatorAddress,
-			Validator: delegation.ValidatorDstAddress,
-			Amount:    amount.Div(precisionDiv),
-			CreatedAt: t,
-		})
+	r := genesisReader{dec: json.NewDecoder(body)}
+	err = r.object(map[string]func() error{
+		"app_state": state.readAppState(r),
+		// the `/genesis` RPC wraps the document into a json-rpc response
+		"result": func() error {
+			return r.object(map[string]func() error{
+				"genesis": func() error {
+					return r.object(map[string]func() error{
+						"app_state": state.readAppState(r),
+					})
+				},
+			})
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("read: %s", err.Error())
+	}
+
+	err = state.saveDelegations(true)
+	if err != nil {
+		return err
 	}
-	accountDelegation := make(map[string]decimal.Decimal)
-	for _, delegation := range delegations {
-		accountDelegation[delegation.Delegator] = accountDelegation[delegation.Delegator].Add(delegation.Amount)
+	err = state.saveValidators()
+	if err != nil {
+		return err
 	}
-	for _, account := range state.AppState.Accounts {
-		amount, _ := calculateAtomAmount(account.Coins)
-		accounts = append(accounts, dmodels.Account{
-			Address:   account.Address,
-			Balance:   amount,
-			Stake:     accountDelegation[account.Address],
-			CreatedAt: t,
+	return state.saveAccounts()
+}
+
+// readGenesisTime reads only the genesis time. It is the first key of the document,
+// so the pass stops early and the delegations of the second pass are saved with the time right away.
+func (p *Parser) readGenesisTime() (genesisTime time.Time, err error) {
+	body, err := openGenesis(p.cfg)
+	if err != nil {
+		return genesisTime, fmt.Errorf("openGenesis: %s", err.Error())
+	}
+	defer body.Close()
+	r := genesisReader{dec: json.NewDecoder(body)}
+	readTime := func() error {
+		err := r.dec.Decode(&genesisTime)
+		if err != nil {
+			return fmt.Errorf("genesis_time: %s", err.Error())
+		}
+		return errGenesisTimeRead
+	}
+	err = r.object(map[string]func() error{
+		"genesis_time": readTime,
+		"result": func() error {
+			return r.object(map[string]func() error{
+				"genesis": func() error {
+					return r.object(map[string]func() error{
+						"genesis_time": readTime,
+					})
+				},
+			})
+		},
+	})
+	if err != nil && err != errGenesisTimeRead {
+		return genesisTime, fmt.Errorf("read: %s", err.Error())
+	}
+	if genesisTime.IsZero() {
+		return genesisTime, fmt.Errorf("genesis_time not found")
+	}
+	return genesisTime, nil
+}
+
+func (s *genesisState) readAppState(r genesisReader) func() error {
+	return func() error {
+		return r.object(map[string]func() error{
+			// legacy genesis (cosmoshub-1) keeps the coins in the accounts
+			"accounts": func() error {
+				return r.array(func() error {
+					var item GenesisAccount
+					if err := r.dec.Decode(&item); err != nil {
+						return fmt.Errorf("accounts: %s", err.Error())
+					}
+					s.addAccount(item)
+					return nil
+				})
+			},
+			"bank": func() error {
+				return r.object(map[string]func() error{
+					"balances": func() error {
+						return r.array(func() error {
+							var item GenesisAccount
+							if err := r.dec.Decode(&item); err != nil {
+								return fmt.Errorf("bank.balances: %s", err.Error())
+							}
+							s.addAccount(item)
+							return nil
+						})
+					},
+				})
+			},
+			"staking": func() error {
+				var delegations, redelegations int
+				return r.object(map[string]func() error{
+					"delegations": func() error {
+						return r.array(func() error {
+							var item GenesisDelegation
+							if err := r.dec.Decode(&item); err != nil {
+								return fmt.Errorf("staking.delegations: %s", err.Error())
+							}
+							s.addDelegation(dmodels.Delegation{
+								ID:        makeHash(fmt.Sprintf("delegations.%d", delegations)),
+								Delegator: item.DelegatorAddress,
+								Validator: item.ValidatorAddress,
+								Amount:    item.Shares.Div(s.p.cfg.Chain.PrecisionDiv()),
+							})
+							delegations++
+							return s.saveDelegations(false)
+						})
+					},
+					"validators": func() error {
+						return r.array(func() error {
+							var item GenesisValidator
+							if err := r.dec.Decode(&item); err != nil {
+								return fmt.Errorf("staking.validators: %s", err.Error())
+							}
+							return s.addValidator(item)
+						})
+					},
+					"redelegations": func() error {
+						return r.array(func() error {
+							var item GenesisRedelegation
+							if err := r.dec.Decode(&item); err != nil {
+								return fmt.Errorf("staking.redelegations: %s", err.Error())
+							}
+							amount := decimal.Zero
+							for _, entry := range item.Entries {
+								amount = amount.Add(entry.SharesDst)
+							}
+							// ignore undelegation
+							s.addDelegation(dmodels.Delegation{
+								ID:        makeHash(fmt.Sprintf("redelegations.%d", redelegations)),
+								Delegator: item.DelegatorAddress,
+								Validator: item.ValidatorDstAddress,
+								Amount:    amount.Div(s.p.cfg.Chain.PrecisionDiv()),
+							})
+							redelegations++
+							return s.saveDelegations(false)
+						})
+					},
+				})
+			},
+			"genutil": func() error {
+				var gentxs int
+				return r.object(map[string]func() error{
+					"gen_txs": func() error {
+						return r.array(func() error {
+							var item GenesisTx
+							if err := r.dec.Decode(&item); err != nil {
+								return fmt.Errorf("genutil.gen_txs: %s", err.Error())
+							}
+							for _, msg := range item.Body.Messages {
+								err := s.addGenesisTxMsg(gentxs, msg)
+								if err != nil {
+									return fmt.Errorf("genutil.gen_txs: %s", err.Error())
+								}
+								gentxs++
+							}
+							return s.saveDelegations(false)
+						})
+					},
+				})
+			},
 		})
 	}
+}
+
+func (s *genesisState) addAccount(item GenesisAccount) {
+	amount, _ := s.p.denoms.baseAmount(item.Coins)
+	s.balances[item.Address] = s.balances[item.Address].Add(amount)
+}
+
+// addGenesisTxMsg adds the validator and the self-bond of a MsgCreateValidator of a gentx.
+// An exported genesis has no gentxs, its validators come from `staking.validators`; an already added validator is skipped.
+func (s *genesisState) addGenesisTxMsg(index int, msg json.RawMessage) error {
+	var header struct {
+		Type string `json:"@type"`
+	}
+	err := json.Unmarshal(msg, &header)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal: %s", err.Error())
+	}
+	if header.Type != CreateValidatorMsg {
+		return nil
+	}
+	var m MsgCreateValidator
+	err = json.Unmarshal(msg, &m)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal: %s", err.Error())
+	}
+	if s.operators[m.ValidatorAddress] {
+		return nil
+	}
+	validator := GenesisValidator{
+		OperatorAddress:   m.ValidatorAddress,
+		ConsensusPubkey:   m.Pubkey,
+		Description:       m.Description,
+		MinSelfDelegation: m.MinSelfDelegation,
+	}
+	validator.Commission.CommissionRates = m.Commission
+	err = s.addValidator(validator)
+	if err != nil {
+		return err
+	}
+	selfBond, err := s.p.denoms.baseAmount([]Amount{m.Value})
+	if err != nil {
+		return fmt.Errorf("baseAmount: %s", err.Error())
+	}
+	// the self-bond is delivered at the first block, so it leaves the genesis balance
+	s.balances[m.DelegatorAddress] = s.balances[m.DelegatorAddress].Sub(selfBond)
+	s.addDelegation(dmodels.Delegation{
+		ID:        makeHash(fmt.Sprintf("gen_txs.%d", index)),
+		Delegator: m.DelegatorAddress,
+		Validator: m.ValidatorAddress,
+		Amount:    selfBond,
+	})
+	return nil
+}
+
+func (s *genesisState) addValidator(item GenesisValidator) error {
+	consAddress, err := helpers.GetHexAddressFromBase64PK(item.ConsensusPubkey.Key)
+	if err != nil {
+		return fmt.Errorf("staking.validators: helpers.GetHexAddressFromBase64PK: %s", err.Error())
+	}
+	address, err := helpers.ConvertBech32(item.OperatorAddress, s.p.cfg.Chain.ValidatorPrefix, s.p.cfg.Chain.AccountPrefix)
+	if err != nil {
+		return fmt.Errorf("staking.validators: helpers.ConvertBech32: %s", err.Error())
+	}
+	s.operators[item.OperatorAddress] = true
+	s.validators = append(s.validators, validatorEvent{event: dmodels.ValidatorEvent{
+		ID:                makeHash(fmt.Sprintf("validators.%d", len(s.validators))),
+		TxHash:            "genesis",
+		Type:              dmodels.ValidatorEventCreate,
+		OperatorAddress:   item.OperatorAddress,
+		Address:           address,
+		ConsAddress:       consAddress,
+		ConsPubKey:        item.ConsensusPubkey.Key,
+		Moniker:           item.Description.Moniker,
+		Identity:          item.Description.Identity,
+		Website:           item.Description.Website,
+		Details:           item.Description.Details,
+		CommissionRate:    item.Commission.CommissionRates.Rate,
+		MaxRate:           item.Commission.CommissionRates.MaxRate,
+		MaxChangeRate:     item.Commission.CommissionRates.MaxChangeRate,
+		MinSelfDelegation: item.MinSelfDelegation.Div(s.p.cfg.Chain.PrecisionDiv()),
+	}})
+	return nil
+}
+
+func (s *genesisState) addDelegation(delegation dmodels.Delegation) {
+	delegation.TxHash = "genesis"
+	s.stakes[delegation.Delegator] = s.stakes[delegation.Delegator].Add(delegation.Amount)
+	s.delegations = append(s.delegations, delegation)
+}
+
+// saveDelegations saves full batches of the buffered delegations, or all of them when force is set.
+func (s *genesisState) saveDelegations(force bool) error {
+	for len(s.delegations) >= saveGenesisBatch || (force && len(s.delegations) > 0) {
+		count := saveGenesisBatch
+		if count > len(s.delegations) {
+			count = len(s.delegations)
+		}
+		for i := 0; i < count; i++ {
+			s.delegations[i].CreatedAt = s.time
+		}
+		err := s.p.dao.CreateDelegations(s.delegations[:count])
+		if err != nil {
+			return fmt.Errorf("dao.CreateDelegations: %s", err.Error())
+		}
+		s.delegations = s.delegations[count:]
+	}
+	return nil
+}
+
+// saveValidators saves the validators of an exported genesis as their create events.
+func (s *genesisState) saveValidators() error {
+	for i := range s.validators {
+		s.validators[i].event.CreatedAt = dmodels.NewTime(s.time)
+	}
+	err := s.p.saveValidatorEvents(s.validators)
+	if err != nil {
+		return fmt.Errorf("saveValidatorEvents: %s", err.Error())
+	}
+	return nil
+}
 
-	for i := 0; i < len(accounts); i += saveGenesisBatch {
-		endOfPart := i + saveGenesisBatch
-		if i+saveGenesisBatch > len(accounts) {
-			endOfPart = len(accounts)
+func (s *genesisState) saveAccounts() error {
+	accounts := make([]dmodels.Account, 0, saveGenesisBatch)
+	for address, balance := range s.balances {
+		accounts = append(accounts, dmodels.Account{
+			Address:   address,
+			Balance:   balance,
+			Stake:     s.stakes[address],
+			CreatedAt: s.time,
+		})
+		if len(accounts) < saveGenesisBatch {
+			continue
 		}
-		err := p.dao.CreateAccounts(accounts[i:endOfPart])
+		err := s.p.dao.CreateAccounts(accounts)
+		if err != nil {
+			return fmt.Errorf("dao.CreateAccounts: %s", err.Error())
+		}
+		accounts = accounts[:0]
+	}
+	if len(accounts) != 0 {
+		err := s.p.dao.CreateAccounts(accounts)
 		if err != nil {
 			return fmt.Errorf("dao.CreateAccounts: %s", err.Error())
 		}
 	}
+	return nil
+}
 
-	for i := 0; i < len(delegations); i += saveGenesisBatch {
-		endOfPart := i + saveGenesisBatch
-		if i+saveGenesisBatch > len(delegations) {
-			endOfPart = len(delegations)
+// object reads a json object calling the handler of every known key and skipping the others.
+func (r genesisReader) object(fields map[string]func() error) error {
+	if err := r.delim('{'); err != nil {
+		return err
+	}
+	for r.dec.More() {
+		token, err := r.dec.Token()
+		if err != nil {
+			return fmt.Errorf("dec.Token: %s", err.Error())
+		}
+		key, _ := token.(string)
+		handler, ok := fields[key]
+		if !ok {
+			err = r.skip()
+		} else {
+			err = handler()
 		}
-		err := p.dao.CreateDelegations(delegations[i:endOfPart])
 		if err != nil {
-			return fmt.Errorf("dao.CreateDelegations: %s", err.Error())
+			return err
 		}
 	}
+	return r.delim('}')
+}
 
+// array reads a json array calling the handler for every element.
+func (r genesisReader) array(handler func() error) error {
+	if err := r.delim('['); err != nil {
+		return err
+	}
+	for r.dec.More() {
+		if err := handler(); err != nil {
+			return err
+		}
+	}
+	return r.delim(']')
+}
+
+func (r genesisReader) delim(expected json.Delim) error {
+	token, err := r.dec.Token()
+	if err != nil {
+		return fmt.Errorf("dec.Token: %s", err.Error())
+	}
+	if d, ok := token.(json.Delim); !ok || d != expected {
+		return fmt.Errorf("expected %s, got %v", expected, token)
+	}
 	return nil
 }
+
+// skip consumes the next value without decoding it.
+func (r genesisReader) skip() error {
+	depth := 0
+	for {
+		token, err := r.dec.Token()
+		if err != nil {
+			return fmt.Errorf("dec.Token: %s", err.Error())
+		}
+		if d, ok := token.(json.Delim); ok {
+			switch d {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
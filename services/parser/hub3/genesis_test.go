@@ -0,0 +1,100 @@
+package hub3
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/kwanifi/numiscan-api/config"
+	"github.com/shopspring/decimal"
+)
+
+func TestReadGenesisTime(t *testing.T) {
+	dir, err := ioutil.TempDir("", "genesis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	documents := map[string]string{
+		"genesis.json": `{"genesis_time":"2021-02-18T06:00:00Z","chain_id":"test","app_state":{"bank":{}}}`,
+		"rpc.json":     `{"jsonrpc":"2.0","id":-1,"result":{"genesis":{"genesis_time":"2021-02-18T06:00:00Z","app_state":{}}}}`,
+		// the first pass stops at the time, the rest of the document is not read
+		"truncated.json": `{"genesis_time":"2021-02-18T06:00:00Z","app_state":{"bank":`,
+	}
+	for name, document := range documents {
+		path := filepath.Join(dir, name)
+		err = ioutil.WriteFile(path, []byte(document), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var p Parser
+		p.cfg.Chain.Genesis = path
+		genesisTime, err := p.readGenesisTime()
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		if !genesisTime.Equal(time.Date(2021, 2, 18, 6, 0, 0, 0, time.UTC)) {
+			t.Error(name, "wrong time", genesisTime)
+		}
+	}
+}
+
+func TestAddGenesisTxMsg(t *testing.T) {
+	operator, err := bech32.ConvertAndEncode(config.DefaultChain.ValidatorPrefix, make([]byte, 20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	delegator, err := bech32.ConvertAndEncode(config.DefaultChain.AccountPrefix, make([]byte, 20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := fmt.Sprintf(`{
+		"@type":"/cosmos.staking.v1beta1.MsgCreateValidator",
+		"description":{"moniker":"genesis validator"},
+		"commission":{"rate":"0.1","max_rate":"0.2","max_change_rate":"0.01"},
+		"min_self_delegation":"1",
+		"delegator_address":"%s",
+		"validator_address":"%s",
+		"pubkey":{"@type":"/cosmos.crypto.ed25519.PubKey","key":"%s"},
+		"value":{"denom":"uatom","amount":"5000000"}
+	}`, delegator, operator, base64.StdEncoding.EncodeToString(make([]byte, 32)))
+
+	p := &Parser{cfg: config.Config{Chain: config.DefaultChain}, denoms: newDenoms(nil, config.DefaultChain, nil)}
+	s := &genesisState{
+		p:         p,
+		balances:  map[string]decimal.Decimal{delegator: decimal.NewFromInt(8)},
+		stakes:    make(map[string]decimal.Decimal),
+		operators: make(map[string]bool),
+	}
+	err = s.addGenesisTxMsg(0, []byte(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.addGenesisTxMsg(1, []byte(`{"@type":"/cosmos.bank.v1beta1.MsgSend"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.validators) != 1 || s.validators[0].event.OperatorAddress != operator ||
+		s.validators[0].event.Moniker != "genesis validator" || !s.validators[0].event.CommissionRate.Equal(decimal.NewFromFloat(0.1)) {
+		t.Fatal("wrong validators", s.validators)
+	}
+	if len(s.delegations) != 1 || !s.delegations[0].Amount.Equal(decimal.NewFromInt(5)) || s.delegations[0].Delegator != delegator {
+		t.Fatal("wrong self-bond", s.delegations)
+	}
+	if !s.balances[delegator].Equal(decimal.NewFromInt(3)) || !s.stakes[delegator].Equal(decimal.NewFromInt(5)) {
+		t.Error("the self-bond should move from the balance to the stake", s.balances[delegator], s.stakes[delegator])
+	}
+
+	err = s.addGenesisTxMsg(2, []byte(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.validators) != 1 || len(s.delegations) != 1 {
+		t.Error("a known validator should be skipped")
+	}
+}
@@ -0,0 +1,277 @@
+package hub3
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	ibctransfertypes "github.com/cosmos/cosmos-sdk/x/ibc/applications/transfer/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	"github.com/shopspring/decimal"
+	tmtypes "github.com/tendermint/tendermint/proto/tendermint/types"
+	"google.golang.org/grpc"
+)
+
+const grpcTimeout = time.Minute
+
+// GRPCAPI fetches blocks and transactions with the gRPC services of the node.
+type GRPCAPI struct {
+	tendermint tmservice.ServiceClient
+	tx         txtypes.ServiceClient
+	transfer   ibctransfertypes.QueryClient
+	slashing   slashingtypes.QueryClient
+	codec      *txCodec
+}
+
+func NewGRPCAPI(address string) (*GRPCAPI, error) {
+	conn, err := grpc.Dial(address, grpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("grpc.Dial: %s", err.Error())
+	}
+	return &GRPCAPI{
+		tendermint: tmservice.NewServiceClient(conn),
+		tx:         txtypes.NewServiceClient(conn),
+		transfer:   ibctransfertypes.NewQueryClient(conn),
+		slashing:   slashingtypes.NewQueryClient(conn),
+		codec:      newTxCodec(),
+	}, nil
+}
+
+func (api *GRPCAPI) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), grpcTimeout)
+}
+
+func (api *GRPCAPI) GetLatestBlock() (block Block, err error) {
+	ctx, cancel := api.context()
+	defer cancel()
+	resp, err := api.tendermint.GetLatestBlock(ctx, &tmservice.GetLatestBlockRequest{})
+	if err != nil {
+		return block, fmt.Errorf("tendermint.GetLatestBlock: %s", err.Error())
+	}
+	return makeBlock(resp.BlockId, resp.Block), nil
+}
+
+func (api *GRPCAPI) GetBlock(height uint64) (block Block, err error) {
+	ctx, cancel := api.context()
+	defer cancel()
+	resp, err := api.tendermint.GetBlockByHeight(ctx, &tmservice.GetBlockByHeightRequest{Height: int64(height)})
+	if err != nil {
+		return block, fmt.Errorf("tendermint.GetBlockByHeight: %s", err.Error())
+	}
+	return makeBlock(resp.BlockId, resp.Block), nil
+}
+
+func (api *GRPCAPI) GetTx(hash string) (tx Tx, err error) {
+	ctx, cancel := api.context()
+	defer cancel()
+	resp, err := api.tx.GetTx(ctx, &txtypes.GetTxRequest{Hash: hash})
+	if err != nil {
+		return tx, fmt.Errorf("tx.GetTx: %s", err.Error())
+	}
+	return api.makeTx(resp.Tx, resp.TxResponse)
+}
+
+// GetBlockTxs searches the transactions by height page by page, they are returned in the order of the block.
+func (api *GRPCAPI) GetBlockTxs(block Block) (txs []Tx, err error) {
+	if len(block.Block.Data.Txs) == 0 {
+		return nil, nil
+	}
+	ctx, cancel := api.context()
+	defer cancel()
+	var items []*txtypes.Tx
+	var responses []*sdk.TxResponse
+	for {
+		resp, err := api.tx.GetTxsEvent(ctx, &txtypes.GetTxsEventRequest{
+			Events:     []string{fmt.Sprintf("tx.height=%d", block.Block.Header.Height)},
+			Pagination: &query.PageRequest{Offset: uint64(len(responses)), Limit: rpcPerPage, CountTotal: true},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("tx.GetTxsEvent: %s", err.Error())
+		}
+		if len(resp.Txs) != len(resp.TxResponses) {
+			return nil, fmt.Errorf("tx.GetTxsEvent: got %d txs for %d responses", len(resp.Txs), len(resp.TxResponses))
+		}
+		items = append(items, resp.Txs...)
+		responses = append(responses, resp.TxResponses...)
+		total := uint64(len(block.Block.Data.Txs))
+		if resp.Pagination != nil && resp.Pagination.Total != 0 {
+			total = resp.Pagination.Total
+		}
+		if len(resp.TxResponses) == 0 || uint64(len(responses)) >= total {
+			break
+		}
+	}
+	if len(responses) != len(block.Block.Data.Txs) {
+		return nil, fmt.Errorf("tx.GetTxsEvent: got %d txs for %d in the block", len(responses), len(block.Block.Data.Txs))
+	}
+	byHash := make(map[string]Tx)
+	for i := range responses {
+		tx, err := api.makeTx(items[i], responses[i])
+		if err != nil {
+			return nil, err
+		}
+		byHash[tx.TxResponse.Hash] = tx
+	}
+	for _, hash := range blockTxHashes(block) {
+		tx, ok := byHash[hash]
+		if !ok {
+			return nil, fmt.Errorf("tx %s not found", hash)
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+func (api *GRPCAPI) makeTx(raw *txtypes.Tx, resp *sdk.TxResponse) (tx Tx, err error) {
+	if raw == nil || resp == nil {
+		return tx, fmt.Errorf("empty tx")
+	}
+	tx.TxResponse.Height = uint64(resp.Height)
+	tx.TxResponse.Hash = resp.TxHash
+	tx.TxResponse.Data = resp.Data
+	tx.TxResponse.RawLog = resp.RawLog
+	tx.TxResponse.Code = int64(resp.Code)
+	tx.TxResponse.GasWanted = uint64(resp.GasWanted)
+	tx.TxResponse.GasUsed = uint64(resp.GasUsed)
+	tx.TxResponse.Timestamp, err = time.Parse(time.RFC3339, resp.Timestamp)
+	if err != nil {
+		return tx, fmt.Errorf("tx %s: time.Parse: %s", resp.TxHash, err.Error())
+	}
+	logs, err := json.Marshal(resp.Logs)
+	if err != nil {
+		return tx, fmt.Errorf("tx %s: json.Marshal: %s", resp.TxHash, err.Error())
+	}
+	err = json.Unmarshal(logs, &tx.TxResponse.Logs)
+	if err != nil {
+		return tx, fmt.Errorf("tx %s: json.Unmarshal: %s", resp.TxHash, err.Error())
+	}
+	err = api.codec.fill(raw.Body, raw.AuthInfo, raw.Signatures, &tx)
+	if err != nil {
+		return tx, fmt.Errorf("tx %s: %s", resp.TxHash, err.Error())
+	}
+	return tx, nil
+}
+
+// GetValidatorset pages the set by offset, see API.GetValidatorset.
+func (api *GRPCAPI) GetValidatorset(height uint64) (set Validatorsets, err error) {
+	ctx, cancel := api.context()
+	defer cancel()
+	for {
+		resp, err := api.tendermint.GetValidatorSetByHeight(ctx, &tmservice.GetValidatorSetByHeightRequest{
+			Height:     int64(height),
+			Pagination: &query.PageRequest{Offset: uint64(len(set.Validators)), Limit: rpcPerPage},
+		})
+		if err != nil {
+			if len(set.Validators) != 0 && pageOutOfRange(err) {
+				return set, nil
+			}
+			return set, fmt.Errorf("tendermint.GetValidatorSetByHeight: %s", err.Error())
+		}
+		for _, v := range resp.Validators {
+			var item ValidatorsetItem
+			item.Address = v.Address
+			item.VotingPower = decimal.NewFromInt(v.VotingPower)
+			if v.PubKey != nil {
+				var pk ed25519.PubKey
+				err = pk.Unmarshal(v.PubKey.Value)
+				if err != nil {
+					return set, fmt.Errorf("validator %s: pk.Unmarshal: %s", v.Address, err.Error())
+				}
+				item.PubKey.Type = v.PubKey.TypeUrl
+				item.PubKey.Key = base64.StdEncoding.EncodeToString(pk.Key)
+			}
+			set.Validators = append(set.Validators, item)
+		}
+		if lastValidatorsPage(len(resp.Validators), len(set.Validators), resp.Pagination.GetTotal()) {
+			break
+		}
+	}
+	return set, nil
+}
+
+func (api *GRPCAPI) GetDenomTrace(hash string) (trace DenomTrace, err error) {
+	ctx, cancel := api.context()
+	defer cancel()
+	resp, err := api.transfer.DenomTrace(ctx, &ibctransfertypes.QueryDenomTraceRequest{Hash: hash})
+	if err != nil {
+		return trace, fmt.Errorf("transfer.DenomTrace: %s", err.Error())
+	}
+	if resp.DenomTrace != nil {
+		trace.DenomTrace.Path = resp.DenomTrace.Path
+		trace.DenomTrace.BaseDenom = resp.DenomTrace.BaseDenom
+	}
+	return trace, nil
+}
+
+func (api *GRPCAPI) GetSlashingParams() (params SlashingParams, err error) {
+	ctx, cancel := api.context()
+	defer cancel()
+	resp, err := api.slashing.Params(ctx, &slashingtypes.QueryParamsRequest{})
+	if err != nil {
+		return params, fmt.Errorf("slashing.Params: %s", err.Error())
+	}
+	params.Params.SlashFractionDoubleSign, _ = decimal.NewFromString(resp.Params.SlashFractionDoubleSign.String())
+	params.Params.SlashFractionDowntime, _ = decimal.NewFromString(resp.Params.SlashFractionDowntime.String())
+	return params, nil
+}
+
+// makeBlock converts a tendermint block into the shape returned by the LCD.
+func makeBlock(id *tmtypes.BlockID, b *tmtypes.Block) (block Block) {
+	if id != nil {
+		block.BlockID.Hash = hexString(id.Hash)
+		block.BlockID.Parts.Total = int(id.PartSetHeader.Total)
+		block.BlockID.Parts.Hash = hexString(id.PartSetHeader.Hash)
+	}
+	if b == nil {
+		return block
+	}
+	header := b.Header
+	block.Block.Header.Version.Block = header.Version.Block
+	block.Block.Header.ChainID = header.ChainID
+	block.Block.Header.Height = uint64(header.Height)
+	block.Block.Header.Time = header.Time
+	block.Block.Header.LastBlockID.Hash = hexString(header.LastBlockId.Hash)
+	block.Block.Header.LastBlockID.Parts.Total = int(header.LastBlockId.PartSetHeader.Total)
+	block.Block.Header.LastBlockID.Parts.Hash = hexString(header.LastBlockId.PartSetHeader.Hash)
+	block.Block.Header.ProposerAddress = hexString(header.ProposerAddress)
+	for _, tx := range b.Data.Txs {
+		block.Block.Data.Txs = append(block.Block.Data.Txs, base64.StdEncoding.EncodeToString(tx))
+	}
+	for _, item := range b.Evidence.Evidence {
+		duplicateVote := item.GetDuplicateVoteEvidence()
+		if duplicateVote == nil || duplicateVote.VoteA == nil {
+			continue
+		}
+		var evidence BlockEvidence
+		evidence.Type = "tendermint/DuplicateVoteEvidence"
+		evidence.Value.VoteA.Height = uint64(duplicateVote.VoteA.Height)
+		evidence.Value.VoteA.ValidatorAddress = hexString(duplicateVote.VoteA.ValidatorAddress)
+		evidence.Value.ValidatorPower = duplicateVote.ValidatorPower
+		block.Block.Evidence.Evidence = append(block.Block.Evidence.Evidence, evidence)
+	}
+	if b.LastCommit != nil {
+		block.Block.LastCommit.Height = strconv.FormatInt(b.LastCommit.Height, 10)
+		block.Block.LastCommit.Round = int(b.LastCommit.Round)
+		block.Block.LastCommit.BlockID.Hash = hexString(b.LastCommit.BlockID.Hash)
+		for _, signature := range b.LastCommit.Signatures {
+			block.Block.LastCommit.Signatures = append(block.Block.LastCommit.Signatures, struct {
+				ValidatorAddress string `json:"validator_address"`
+			}{ValidatorAddress: hexString(signature.ValidatorAddress)})
+		}
+	}
+	return block
+}
+
+func hexString(b []byte) string {
+	return strings.ToUpper(hex.EncodeToString(b))
+}
@@ -0,0 +1,59 @@
+package hub3
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"google.golang.org/grpc"
+)
+
+// testTxService serves the transactions of one block the way tx_search of the node does:
+// at most rpcPerPage of them per request.
+type testTxService struct {
+	txtypes.ServiceClient
+	hashes []string
+}
+
+func (s testTxService) GetTxsEvent(ctx context.Context, in *txtypes.GetTxsEventRequest, opts ...grpc.CallOption) (*txtypes.GetTxsEventResponse, error) {
+	limit := in.Pagination.Limit
+	if limit > rpcPerPage {
+		limit = rpcPerPage
+	}
+	resp := &txtypes.GetTxsEventResponse{Pagination: &query.PageResponse{Total: uint64(len(s.hashes))}}
+	for i := in.Pagination.Offset; i < uint64(len(s.hashes)) && i < in.Pagination.Offset+limit; i++ {
+		resp.Txs = append(resp.Txs, &txtypes.Tx{
+			Body:     &txtypes.TxBody{Memo: fmt.Sprintf("tx %d", i)},
+			AuthInfo: &txtypes.AuthInfo{},
+		})
+		resp.TxResponses = append(resp.TxResponses, &sdk.TxResponse{
+			Height:    100,
+			TxHash:    s.hashes[i],
+			Timestamp: "2021-03-01T00:00:00Z",
+		})
+	}
+	return resp, nil
+}
+
+func TestGRPCGetBlockTxsPaging(t *testing.T) {
+	var block Block
+	block.Block.Header.Height = 100
+	for i := 0; i < rpcPerPage*2+5; i++ {
+		block.Block.Data.Txs = append(block.Block.Data.Txs, base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("tx %d", i))))
+	}
+	api := &GRPCAPI{tx: testTxService{hashes: blockTxHashes(block)}, codec: newTxCodec()}
+	txs, err := api.GetBlockTxs(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != len(block.Block.Data.Txs) {
+		t.Fatalf("got %d txs for %d in the block", len(txs), len(block.Block.Data.Txs))
+	}
+	if txs[rpcPerPage*2+4].Tx.Body.Memo != fmt.Sprintf("tx %d", rpcPerPage*2+4) {
+		t.Error("wrong order of the txs", txs[rpcPerPage*2+4].Tx.Body.Memo)
+	}
+}
@@ -3,89 +3,135 @@ package hub3
 import (
 	"context"
 	"crypto/sha1"
-	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/kwanifi/numiscan-api/config"
 	"github.com/kwanifi/numiscan-api/dao"
+	"github.com/kwanifi/numiscan-api/dao/derrors"
 	"github.com/kwanifi/numiscan-api/dao/filters"
 	"github.com/kwanifi/numiscan-api/dmodels"
 	"github.com/kwanifi/numiscan-api/log"
 	"github.com/kwanifi/numiscan-api/services/helpers"
 	"github.com/shopspring/decimal"
-	"github.com/tendermint/tendermint/crypto"
-	"github.com/tendermint/tendermint/libs/bytes"
 )
 
 const repeatDelay = time.Second * 5
-const ParserTitle = "hub3"
 
 const batchTxs = 50
-const precision = 6
+const ibcTransferPort = "transfer"
 
-var precisionDiv = decimal.New(1, precision)
+// maxRollbackDepth limits how deep the parser looks for the fork point.
+const maxRollbackDepth = 1000
 
 type (
 	Parser struct {
 		cfg       config.Config
 		api       api
 		dao       dao.DAO
+		denoms    *denoms
+		results   *RPCAPI
+		slashing  slashingQuerier
 		fetcherCh chan uint64
 		saverCh   chan data
-		accounts  map[string]struct{}
-		ctx       context.Context
-		cancel    context.CancelFunc
-		wg        *sync.WaitGroup
+		errCh     chan error
+		// rollbackCh passes the fork height from the saver to the fetching loop after a rollback,
+		// generation is increased on every rollback to drop the data fetched before it.
+		rollbackCh chan uint64
+		generation uint64
+		accounts   map[string]struct{}
+		ctx        context.Context
+		cancel     context.CancelFunc
+		wg         *sync.WaitGroup
 	}
 	api interface {
 		GetLatestBlock() (block Block, err error)
 		GetBlock(height uint64) (block Block, err error)
 		GetTx(hash string) (txs Tx, err error)
+		GetBlockTxs(block Block) (txs []Tx, err error)
 		GetValidatorset(height uint64) (set Validatorsets, err error)
 	}
 	data struct {
-		height           uint64
-		blocks           []dmodels.Block
-		transactions     []dmodels.Transaction
-		transfers        []dmodels.Transfer
-		delegations      []dmodels.Delegation
-		delegatorRewards []dmodels.DelegatorReward
-		validatorRewards []dmodels.ValidatorReward
-		proposals        []dmodels.HistoryProposal
-		proposalVotes    []dmodels.ProposalVote
-		proposalDeposits []dmodels.ProposalDeposit
-		jailers          []dmodels.Jailer
-		missedBlocks     []dmodels.MissedBlock
+		height            uint64
+		generation        uint64
+		lastBlockHash     string
+		blocks            []dmodels.Block
+		transactions      []dmodels.Transaction
+		transfers         []dmodels.Transfer
+		delegations       []dmodels.Delegation
+		delegatorRewards  []dmodels.DelegatorReward
+		validatorRewards  []dmodels.ValidatorReward
+		proposals         []dmodels.HistoryProposal
+		proposalVotes     []dmodels.ProposalVote
+		proposalDeposits  []dmodels.ProposalDeposit
+		jailers           []dmodels.Jailer
+		missedBlocks      []dmodels.MissedBlock
+		ibcTransfers      []dmodels.IBCTransfer
+		fees              []dmodels.TransactionFee
+		validatorEvents   []validatorEvent
+		slashes           []dmodels.Slash
+		validatorSets     []validatorSet
+		validatorRevenues []dmodels.ValidatorRevenue
+		redelegations     []dmodels.Redelegation
+		unbondings        []dmodels.Unbonding
+		denoms            *denoms
 	}
 )
 
-func NewParser(cfg config.Config, d dao.DAO) *Parser {
+func NewParser(cfg config.Config, d dao.DAO) (*Parser, error) {
+	lcd := NewAPI(cfg.Parser.Node)
+	var source api = lcd
+	var resolver denomResolver = lcd
+	var slashing slashingQuerier = lcd
+	switch cfg.Parser.Source {
+	case config.ParserSourceRPC:
+		source = NewRPCAPI(cfg.Parser.RPC)
+	case config.ParserSourceGRPC:
+		grpcAPI, err := NewGRPCAPI(cfg.Parser.GRPC)
+		if err != nil {
+			return nil, fmt.Errorf("NewGRPCAPI: %s", err.Error())
+		}
+		source = grpcAPI
+		resolver = grpcAPI
+		slashing = grpcAPI
+	}
+	// the block events are served only by the tendermint RPC
+	var results *RPCAPI
+	if cfg.Parser.RPC != "" {
+		results = NewRPCAPI(cfg.Parser.RPC)
+	} else {
+		log.Warn("Parser: parser.rpc is not set, slashes and validator revenues are not parsed")
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	return &Parser{
-		cfg:       cfg,
-		dao:       d,
-		api:       NewAPI(cfg.Parser.Node),
-		fetcherCh: make(chan uint64, 5000),
-		saverCh:   make(chan data, 5000),
-		accounts:  make(map[string]struct{}),
-		ctx:       ctx,
-		cancel:    cancel,
-		wg:        &sync.WaitGroup{},
-	}
+		cfg:        cfg,
+		dao:        d,
+		api:        source,
+		denoms:     newDenoms(resolver, cfg.Chain, cfg.Parser.Denoms),
+		results:    results,
+		slashing:   slashing,
+		fetcherCh:  make(chan uint64, 5000),
+		saverCh:    make(chan data, 5000),
+		errCh:      make(chan error, 1),
+		rollbackCh: make(chan uint64, 1),
+		accounts:   make(map[string]struct{}),
+		ctx:        ctx,
+		cancel:     cancel,
+		wg:         &sync.WaitGroup{},
+	}, nil
 }
 
 func (p *Parser) Run() error {
-	model, err := p.dao.GetParser(ParserTitle)
+	model, err := p.getParser()
 	if err != nil {
-		return fmt.Errorf("parser not found")
+		return fmt.Errorf("getParser: %s", err.Error())
 	}
 	for i := uint64(0); i < p.cfg.Parser.Fetchers; i++ {
 		go p.runFetcher()
@@ -98,26 +144,74 @@ func (p *Parser) Run() error {
 		p.setAccounts()
 	}
 	go p.saving()
+
+	// new heights are pushed by the websocket subscription, polling only fills the gaps
+	heads := make(chan uint64, 100)
+	pollInterval := time.Second
+	if p.cfg.Parser.Stream {
+		go p.subscribe(heads)
+		pollInterval = streamPollInterval
+	}
+	poll := time.After(0)
 	for {
-		latestBlock, err := p.api.GetLatestBlock()
-		if err != nil {
-			log.Error("Parser: api.GetLatestBlock: %s", err.Error())
-			continue
-		}
-		if model.Height >= latestBlock.Block.Header.Height {
-			<-time.After(time.Second)
+		var latest uint64
+		select {
+		case <-p.ctx.Done():
+			return nil
+		case err = <-p.errCh:
+			return err
+		case fork := <-p.rollbackCh:
+			model.Height = p.resetFetching(fork)
 			continue
+		case latest = <-heads:
+		case <-poll:
+			poll = time.After(pollInterval)
+			latestBlock, err := p.api.GetLatestBlock()
+			if err != nil {
+				log.Error("Parser: api.GetLatestBlock: %s", err.Error())
+				continue
+			}
+			latest = latestBlock.Block.Header.Height
 		}
-		for ; model.Height < latestBlock.Block.Header.Height; model.Height++ {
+		for model.Height < latest {
 			select {
 			case <-p.ctx.Done():
 				return nil
+			case err = <-p.errCh:
+				return err
+			case fork := <-p.rollbackCh:
+				model.Height = p.resetFetching(fork)
 			case p.fetcherCh <- model.Height + 1:
+				model.Height++
 			}
 		}
 	}
 }
 
+// resetFetching drops the queued heights after a rollback, so the fetchers continue from the fork.
+func (p *Parser) resetFetching(fork uint64) uint64 {
+	for {
+		select {
+		case <-p.fetcherCh:
+		default:
+			return fork
+		}
+	}
+}
+
+// getParser returns the parser state of the configured chain, creating it on the first run.
+func (p *Parser) getParser() (model dmodels.Parser, err error) {
+	model, err = p.dao.GetParser(p.cfg.Chain.Title)
+	if err == nil || err.Error() != derrors.ErrNotFound {
+		return model, err
+	}
+	err = p.dao.CreateParser(dmodels.Parser{Title: p.cfg.Chain.Title})
+	if err != nil {
+		return model, fmt.Errorf("dao.CreateParser: %s", err.Error())
+	}
+	return p.dao.GetParser(p.cfg.Chain.Title)
+}
+
 func (p *Parser) Title() string {
 	return "Parser"
 }
@@ -139,6 +233,8 @@ func (p *Parser) runFetcher() {
 		for {
 			var d data
 			d.height = height
+			d.generation = atomic.LoadUint64(&p.generation)
+			d.denoms = p.denoms
 			block, err := p.api.GetBlock(height)
 			if err != nil {
 				log.Error("Parser: fetcher: api.GetBlock: %s", err.Error())
@@ -152,6 +248,7 @@ func (p *Parser) runFetcher() {
 				continue
 			}
 
+			d.lastBlockHash = block.Block.Header.LastBlockID.Hash
 			d.blocks = append(d.blocks, dmodels.Block{
 				ID:        block.Block.Header.Height,
 				Hash:      block.BlockID.Hash,
@@ -161,6 +258,7 @@ func (p *Parser) runFetcher() {
 
 			// find missed blocks
 			set := make(map[string]struct{})
+			powers := validatorSet{height: height, createdAt: block.Block.Header.Time, powers: make(map[string]uint64)}
 			for _, s := range validatorsSets.Validators {
 				address, err := helpers.GetHexAddressFromBase64PK(s.PubKey.Key)
 				if err != nil {
@@ -168,7 +266,9 @@ func (p *Parser) runFetcher() {
 					continue
 				}
 				set[address] = struct{}{}
+				powers.powers[address] = uint64(s.VotingPower.IntPart())
 			}
+			d.validatorSets = append(d.validatorSets, powers)
 
 			precommits := make(map[string]struct{})
 			for _, precommit := range block.Block.LastCommit.Signatures {
@@ -188,43 +288,85 @@ func (p *Parser) runFetcher() {
 				}
 			}
 
+			if p.results != nil {
+				results, err := p.results.GetBlockResults(height)
+				if err != nil {
+					log.Error("Parser: fetcher: results.GetBlockResults: %s", err.Error())
+					<-time.After(time.Second)
+					continue
+				}
+				err = d.parseSlashes(block, results, p.slashing, p.cfg.Chain)
+				if err != nil {
+					log.Error("Parser: fetcher: parseSlashes: %s", err.Error())
+					<-time.After(time.Second)
+					continue
+				}
+				err = d.parseValidatorRevenues(block, results)
+				if err != nil {
+					log.Error("Parser: fetcher: parseValidatorRevenues: %s", err.Error())
+					<-time.After(time.Second)
+					continue
+				}
+			}
+
+			txs, err := p.api.GetBlockTxs(block)
+			if err != nil {
+				log.Error("Parser: fetcher: api.GetBlockTxs: %s", err.Error())
+				<-time.After(time.Second)
+				continue
+			}
+
 			fail := false
-			for _, txData := range block.Block.Data.Txs {
-				decodedTx, _ := base64.StdEncoding.DecodeString(txData)
-				sha256Hash := crypto.Sha256(decodedTx)
-				hash := bytes.HexBytes(sha256Hash)
+			for _, tx := range txs {
+				success := tx.TxResponse.Code == 0
 
-				tx, err := p.api.GetTx(hash.String())
-				if err != nil {
-					log.Error("Parser: fetcher: api.GetTxs: %s", err.Error())
+				if tx.TxResponse.Hash == "" {
+					log.Error("Parser: fetcher: empty tx hash")
 					<-time.After(time.Second)
 					fail = true
 					break
 				}
 
-				success := tx.TxResponse.Code == 0
-
-				fee, err := calculateAtomAmount(tx.Tx.AuthInfo.Fee.Amount)
+				fee, err := d.parseFee(tx)
 				if err != nil {
-					log.Warn("Parser: height: %d, calculateAtomAmount: %s", tx.TxResponse.Height, err.Error())
+					log.Error("Parser: height: %d, parseFee: %s", tx.TxResponse.Height, err.Error())
+					<-time.After(time.Second)
+					fail = true
+					break
 				}
 
-				if tx.TxResponse.Hash == "" {
-					log.Error("Parser: fetcher: empty tx hash")
+				messageTypes, err := tx.messageTypes()
+				if err != nil {
+					log.Error("Parser: height: %d, messageTypes: %s", tx.TxResponse.Height, err.Error())
 					<-time.After(time.Second)
 					fail = true
 					break
 				}
+				signers := p.txSigners(tx)
+				feePayer := tx.Tx.AuthInfo.Fee.Payer
+				if feePayer == "" && len(signers) != 0 {
+					feePayer = signers[0]
+				}
+				var rawLog string
+				if !success {
+					rawLog = tx.TxResponse.RawLog
+				}
 
 				d.transactions = append(d.transactions, dmodels.Transaction{
-					Hash:      tx.TxResponse.Hash,
-					Status:    success,
-					Height:    tx.TxResponse.Height,
-					Messages:  uint64(len(tx.TxResponse.Tx.Body.Messages)),
-					Fee:       fee,
-					GasUsed:   tx.TxResponse.GasUsed,
-					GasWanted: tx.TxResponse.GasWanted,
-					CreatedAt: tx.TxResponse.Timestamp,
+					Hash:         tx.TxResponse.Hash,
+					Status:       success,
+					Height:       tx.TxResponse.Height,
+					Messages:     uint64(len(tx.TxResponse.Tx.Body.Messages)),
+					Fee:          fee,
+					GasUsed:      tx.TxResponse.GasUsed,
+					GasWanted:    tx.TxResponse.GasWanted,
+					Memo:         tx.Tx.Body.Memo,
+					FeePayer:     feePayer,
+					FeeGranter:   tx.Tx.AuthInfo.Fee.Granter,
+					Signers:      signers,
+					MessageTypes: messageTypes,
+					RawLog:       rawLog,
+					CreatedAt:    tx.TxResponse.Timestamp,
 				})
 
 				if success {
@@ -260,6 +402,18 @@ func (p *Parser) runFetcher() {
 							err = d.parseVoteMsg(i, tx, msg)
 						case UnJailMsg:
 							err = d.parseUnjailMsg(i, tx, msg)
+						case CreateValidatorMsg:
+							err = d.parseCreateValidatorMsg(i, tx, msg)
+						case EditValidatorMsg:
+							err = d.parseEditValidatorMsg(i, tx, msg)
+						case IBCTransferMsg:
+							err = d.parseIBCTransferMsg(i, tx, msg)
+						case IBCRecvPacketMsg:
+							err = d.parseIBCRecvPacketMsg(i, tx, msg)
+						case IBCAcknowledgementMsg:
+							err = d.parseIBCAcknowledgementMsg(i, tx, msg)
+						case IBCTimeoutMsg, IBCTimeoutOnCloseMsg:
+							err = d.parseIBCTimeoutMsg(i, tx, msg)
 						}
 						if err != nil {
 							log.Error("Parser: (height: %d): %s", tx.TxResponse.Height, err.Error())
@@ -285,7 +439,7 @@ func (p *Parser) saving() {
 	var model dmodels.Parser
 	for {
 		var err error
-		model, err = p.dao.GetParser(ParserTitle)
+		model, err = p.dao.GetParser(p.cfg.Chain.Title)
 		if err != nil {
 			log.Error("Parser: saving: dao.GetParser: %s", err.Error())
 			<-time.After(time.Second * 5)
@@ -304,149 +458,264 @@ func (p *Parser) saving() {
 		case <-p.ctx.Done():
 			return
 		case d := <-p.saverCh:
-			dataset = append(dataset, d)
+			// blocks fetched before a rollback may belong to the abandoned fork
+			if d.generation == atomic.LoadUint64(&p.generation) && d.height > model.Height {
+				dataset = append(dataset, d)
+			}
 			continue
 		case <-ticker:
-			sort.Slice(dataset, func(i, j int) bool {
-				return dataset[i].height < dataset[j].height
-			})
+			dataset = sortDataset(dataset)
 			ticker = time.After(time.Second * 2)
 		}
 
 		var count int
 		for i, item := range dataset {
-			if item.height == model.Height+uint64(i+1) {
-				count = i + 1
-			} else {
+			if item.height != model.Height+uint64(i+1) {
 				break
 			}
+			// the previous block of the batch is checked against the database on the next iteration
+			if i > 0 && item.lastBlockHash != dataset[i-1].blocks[0].Hash {
+				break
+			}
+			count = i + 1
 		}
 
 		if count == 0 {
 			continue
 		}
 
+		if err := p.checkBlockHash(model.Height, dataset[0].lastBlockHash); err != nil {
+			log.Error("Parser: %s", err.Error())
+			if !p.cfg.Parser.Rollback {
+				p.errCh <- err
+				return
+			}
+			fork, err := p.rollback(model)
+			if err != nil {
+				p.errCh <- err
+				return
+			}
+			log.Warn("Parser: rolled back to height %d", fork)
+			atomic.AddUint64(&p.generation, 1)
+			model.Height = fork
+			dataset = nil
+			p.rollbackCh <- fork
+			continue
+		}
+
 		if count > int(p.cfg.Parser.Batch) {
 			count = int(p.cfg.Parser.Batch)
 		}
 
 		var singleData data
 		for _, item := range dataset[:count] {
-			singleData.blocks = append(singleData.blocks, item.blocks...)
-			singleData.proposals = append(singleData.proposals, item.proposals...)
-			singleData.delegations = append(singleData.delegations, item.delegations...)
-			singleData.jailers = append(singleData.jailers, item.jailers...)
-			singleData.transactions = append(singleData.transactions, item.transactions...)
-			singleData.delegatorRewards = append(singleData.delegatorRewards, item.delegatorRewards...)
-			singleData.validatorRewards = append(singleData.validatorRewards, item.validatorRewards...)
-			singleData.transfers = append(singleData.transfers, item.transfers...)
-			singleData.proposalVotes = append(singleData.proposalVotes, item.proposalVotes...)
-			singleData.proposalDeposits = append(singleData.proposalDeposits, item.proposalDeposits...)
-			singleData.missedBlocks = append(singleData.missedBlocks, item.missedBlocks...)
+			singleData.merge(item)
 		}
 		p.wg.Add(1)
-		var err error
-		for {
-			err = p.dao.CreateBlocks(singleData.blocks)
-			if err == nil {
-				break
-			}
-			log.Error("Parser: dao.CreateBlocks: %s", err.Error())
-			<-time.After(repeatDelay)
-		}
+		p.save(singleData, nil)
+		model.Height += uint64(count)
 		for {
-			err = p.dao.CreateTransactions(singleData.transactions)
+			err := p.dao.UpdateParser(model)
 			if err == nil {
 				break
 			}
-			log.Error("Parser: dao.CreateTransactions: %s", err.Error())
+			log.Error("Parser: dao.UpdateParser: %s", err.Error())
 			<-time.After(repeatDelay)
 		}
-		for {
-			err = p.dao.CreateTransfers(singleData.transfers)
-			if err == nil {
-				break
+		dataset = dataset[count:]
+		p.wg.Done()
+	}
+}
+
+// saver writes one kind of parsed rows, table is the name accepted by `reindex --tables`.
+type saver struct {
+	table string
+	save  func(d data) error
+}
+
+func (p *Parser) savers() []saver {
+	return []saver{
+		{table: dmodels.BlocksTable, save: func(d data) error { return p.dao.CreateBlocks(d.blocks) }},
+		{table: dmodels.TransactionsTable, save: func(d data) error { return p.dao.CreateTransactions(d.transactions) }},
+		{table: dmodels.TransactionFeesTable, save: func(d data) error { return p.dao.CreateTransactionFees(d.fees) }},
+		{table: dmodels.TransfersTable, save: func(d data) error { return p.dao.CreateTransfers(d.transfers) }},
+		{table: dmodels.DelegationsTable, save: func(d data) error { return p.dao.CreateDelegations(d.delegations) }},
+		{table: dmodels.RedelegationsTable, save: func(d data) error { return p.dao.CreateRedelegations(d.redelegations) }},
+		{table: dmodels.UnbondingsTable, save: func(d data) error { return p.dao.CreateUnbondings(d.unbondings) }},
+		{table: dmodels.DelegatorRewardsTable, save: func(d data) error { return p.dao.CreateDelegatorRewards(d.delegatorRewards) }},
+		{table: dmodels.ValidatorRewardsTable, save: func(d data) error { return p.dao.CreateValidatorRewards(d.validatorRewards) }},
+		{table: dmodels.HistoryProposalsTable, save: func(d data) error { return p.dao.CreateHistoryProposals(d.proposals) }},
+		{table: dmodels.ProposalDepositsTable, save: func(d data) error { return p.dao.CreateProposalDeposits(d.proposalDeposits) }},
+		{table: dmodels.ProposalVotesTable, save: func(d data) error { return p.dao.CreateProposalVotes(d.proposalVotes) }},
+		{table: dmodels.JailersTable, save: func(d data) error { return p.dao.CreateJailers(d.jailers) }},
+		{table: dmodels.MissedBlocks, save: func(d data) error { return p.dao.CreateMissedBlocks(d.missedBlocks) }},
+		{table: dmodels.ValidatorEventsTable, save: func(d data) error { return p.saveValidatorEvents(d.validatorEvents) }},
+		{table: dmodels.SlashesTable, save: func(d data) error { return p.dao.CreateSlashes(d.slashes) }},
+		{table: dmodels.ValidatorPowersTable, save: func(d data) error { return p.saveValidatorPowers(d.validatorSets) }},
+		{table: dmodels.ValidatorRevenuesTable, save: func(d data) error { return p.dao.CreateValidatorRevenues(d.validatorRevenues) }},
+		{table: dmodels.IBCTransfersTable, save: func(d data) error {
+			err := p.matchIBCTransfers(d.ibcTransfers)
+			if err != nil {
+				return fmt.Errorf("matchIBCTransfers: %s", err.Error())
 			}
-			log.Error("Parser: dao.CreateTransfers: %s", err.Error())
-			<-time.After(repeatDelay)
+			return p.dao.CreateIBCTransfers(d.ibcTransfers)
+		}},
+	}
+}
+
+// save writes the data to the tables, all of them when tables is empty. Every table is retried until success.
+func (p *Parser) save(d data, tables map[string]bool) {
+	for _, s := range p.savers() {
+		if len(tables) != 0 && !tables[s.table] {
+			continue
 		}
 		for {
-			err = p.dao.CreateDelegations(singleData.delegations)
+			err := s.save(d)
 			if err == nil {
 				break
 			}
-			log.Error("Parser: dao.CreateDelegations: %s", err.Error())
+			log.Error("Parser: saving %s: %s", s.table, err.Error())
 			<-time.After(repeatDelay)
 		}
-		for {
-			err = p.dao.CreateDelegatorRewards(singleData.delegatorRewards)
-			if err == nil {
-				break
-			}
-			log.Error("Parser: dao.CreateDelegatorRewards: %s", err.Error())
-			<-time.After(repeatDelay)
+	}
+	if len(tables) == 0 || tables[dmodels.AccountsTable] {
+		p.saveAccounts(d)
+	}
+}
+
+func (d *data) merge(item data) {
+	d.blocks = append(d.blocks, item.blocks...)
+	d.proposals = append(d.proposals, item.proposals...)
+	d.delegations = append(d.delegations, item.delegations...)
+	d.jailers = append(d.jailers, item.jailers...)
+	d.transactions = append(d.transactions, item.transactions...)
+	d.fees = append(d.fees, item.fees...)
+	d.delegatorRewards = append(d.delegatorRewards, item.delegatorRewards...)
+	d.validatorRewards = append(d.validatorRewards, item.validatorRewards...)
+	d.transfers = append(d.transfers, item.transfers...)
+	d.proposalVotes = append(d.proposalVotes, item.proposalVotes...)
+	d.proposalDeposits = append(d.proposalDeposits, item.proposalDeposits...)
+	d.missedBlocks = append(d.missedBlocks, item.missedBlocks...)
+	d.ibcTransfers = append(d.ibcTransfers, item.ibcTransfers...)
+	d.validatorEvents = append(d.validatorEvents, item.validatorEvents...)
+	d.slashes = append(d.slashes, item.slashes...)
+	d.validatorSets = append(d.validatorSets, item.validatorSets...)
+	d.validatorRevenues = append(d.validatorRevenues, item.validatorRevenues...)
+	d.redelegations = append(d.redelegations, item.redelegations...)
+	d.unbondings = append(d.unbondings, item.unbondings...)
+}
+
+// checkBlockHash compares the last block hash of the next block with the one stored for the height.
+func (p *Parser) checkBlockHash(height uint64, lastBlockHash string) error {
+	if height == 0 {
+		return nil
+	}
+	var blocks []dmodels.Block
+	var err error
+	for {
+		blocks, err = p.dao.GetBlocks(filters.Blocks{Heights: []uint64{height}, Limit: 1})
+		if err == nil {
+			break
 		}
-		for {
-			err = p.dao.CreateValidatorRewards(singleData.validatorRewards)
-			if err == nil {
-				break
-			}
-			log.Error("Parser: dao.CreateValidatorRewards: %s", err.Error())
-			<-time.After(repeatDelay)
+		log.Error("Parser: checkBlockHash: dao.GetBlocks: %s", err.Error())
+		<-time.After(repeatDelay)
+	}
+	if len(blocks) == 0 {
+		log.Warn("Parser: checkBlockHash: block %d not found", height)
+		return nil
+	}
+	if blocks[0].Hash != lastBlockHash {
+		return fmt.Errorf("block hash mismatch: height %d refers to %s, but stored hash of height %d is %s",
+			height+1, lastBlockHash, height, blocks[0].Hash)
+	}
+	return nil
+}
+
+// sortDataset orders the fetched blocks by height, keeping one item per height.
+// A height can be fetched twice when it was queued again after a rollback.
+func sortDataset(dataset []data) []data {
+	sort.SliceStable(dataset, func(i, j int) bool {
+		return dataset[i].height < dataset[j].height
+	})
+	var sorted []data
+	for _, item := range dataset {
+		if len(sorted) != 0 && sorted[len(sorted)-1].height == item.height {
+			sorted[len(sorted)-1] = item
+			continue
 		}
-		for {
-			err = p.dao.CreateHistoryProposals(singleData.proposals)
-			if err == nil {
-				break
-			}
-			log.Error("Parser: dao.CreateProposals: %s", err.Error())
-			<-time.After(repeatDelay)
+		sorted = append(sorted, item)
+	}
+	return sorted
+}
+
+// rollback finds the last height where the stored hash equals the node one, deletes
+// everything parsed above it and returns that height to continue parsing from.
+func (p *Parser) rollback(model dmodels.Parser) (uint64, error) {
+	var fork uint64
+	for height := model.Height; height > 0 && model.Height-height < maxRollbackDepth; height-- {
+		block, err := p.api.GetBlock(height)
+		if err != nil {
+			return 0, fmt.Errorf("rollback: api.GetBlock: %s", err.Error())
 		}
-		for {
-			err = p.dao.CreateProposalDeposits(singleData.proposalDeposits)
-			if err == nil {
-				break
-			}
-			log.Error("Parser: dao.CreateProposalDeposits: %s", err.Error())
-			<-time.After(repeatDelay)
+		blocks, err := p.dao.GetBlocks(filters.Blocks{Heights: []uint64{height}, Limit: 1})
+		if err != nil {
+			return 0, fmt.Errorf("rollback: dao.GetBlocks: %s", err.Error())
 		}
-		for {
-			err = p.dao.CreateProposalVotes(singleData.proposalVotes)
-			if err == nil {
-				break
-			}
-			log.Error("Parser: dao.CreateProposalVotes: %s", err.Error())
-			<-time.After(repeatDelay)
+		if len(blocks) != 0 && blocks[0].Hash == block.BlockID.Hash {
+			fork = height
+			break
 		}
-		for {
-			err = p.dao.CreateJailers(singleData.jailers)
-			if err == nil {
-				break
-			}
-			log.Error("Parser: dao.CreateJailers: %s", err.Error())
-			<-time.After(repeatDelay)
+		log.Warn("Parser: rollback: height %d differs from the node", height)
+	}
+	if fork == 0 {
+		return 0, fmt.Errorf("rollback: fork point not found in the last %d blocks", maxRollbackDepth)
+	}
+	err := p.dao.DeleteBlocksAbove(fork)
+	if err != nil {
+		return 0, fmt.Errorf("rollback: dao.DeleteBlocksAbove: %s", err.Error())
+	}
+	model.Height = fork
+	err = p.dao.UpdateParser(model)
+	if err != nil {
+		return 0, fmt.Errorf("rollback: dao.UpdateParser: %s", err.Error())
+	}
+	return fork, nil
+}
+
+// matchIBCTransfers links acknowledgements and timeouts to their original transfers,
+// so the replaced row keeps the transfer tx hash and creation time.
+func (p *Parser) matchIBCTransfers(transfers []dmodels.IBCTransfer) error {
+	origins := make(map[string]dmodels.IBCTransfer)
+	var ids []string
+	for _, transfer := range transfers {
+		if transfer.AckTxHash != "" {
+			ids = append(ids, transfer.ID)
 		}
-		for {
-			err = p.dao.CreateMissedBlocks(singleData.missedBlocks)
-			if err == nil {
-				break
-			}
-			log.Error("Parser: dao.CreateMissedBlocks: %s", err.Error())
-			<-time.After(repeatDelay)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	stored, err := p.dao.GetIBCTransfers(filters.IBCTransfers{ID: ids})
+	if err != nil {
+		return fmt.Errorf("dao.GetIBCTransfers: %s", err.Error())
+	}
+	for _, transfer := range stored {
+		origins[transfer.ID] = transfer
+	}
+	for i, transfer := range transfers {
+		if transfer.AckTxHash == "" {
+			origins[transfer.ID] = transfer
+			continue
 		}
-		p.saveNewAccounts(singleData)
-		for {
-			model.Height += uint64(count)
-			err = p.dao.UpdateParser(model)
-			if err == nil {
-				break
-			}
-			log.Error("Parser: dao.UpdateParser: %s", err.Error())
-			<-time.After(repeatDelay)
+		origin, ok := origins[transfer.ID]
+		if !ok {
+			log.Warn("Parser: not found origin of ibc transfer %s (sequence %d)", transfer.SourceChannel, transfer.Sequence)
+			continue
 		}
-		dataset = append(dataset[count:])
-		p.wg.Done()
+		transfers[i].TxHash = origin.TxHash
+		transfers[i].CreatedAt = origin.CreatedAt
 	}
+	return nil
 }
 
 func (p *Parser) setAccounts() {
@@ -466,9 +735,13 @@ func (p *Parser) setAccounts() {
 	}
 }
 
-func (p *Parser) saveNewAccounts(data data) {
+// saveAccounts creates the accounts seen for the first time and marks all touched accounts as dirty,
+// so their balances are refreshed by the services.
+func (p *Parser) saveAccounts(data data) {
 	var newAccounts []dmodels.Account
+	touched := make(map[string]struct{})
 	addAccount := func(acc string, tm time.Time) {
+		touched[acc] = struct{}{}
 		_, ok := p.accounts[acc]
 		if !ok {
 			p.accounts[acc] = struct{}{}
@@ -492,6 +765,27 @@ func (p *Parser) saveNewAccounts(data data) {
 	for _, reward := range data.delegatorRewards {
 		addAccount(reward.Delegator, reward.CreatedAt)
 	}
+	// the commission is withdrawn to the account of the operator
+	for _, reward := range data.validatorRewards {
+		address, err := helpers.ConvertBech32(reward.Address, p.cfg.Chain.ValidatorPrefix, p.cfg.Chain.AccountPrefix)
+		if err != nil {
+			log.Warn("Parser: saveAccounts: validator %s: helpers.ConvertBech32: %s", reward.Address, err.Error())
+			continue
+		}
+		addAccount(address, reward.CreatedAt)
+	}
+	for _, tx := range data.transactions {
+		if tx.FeePayer != "" {
+			addAccount(tx.FeePayer, tx.CreatedAt)
+		}
+	}
+	for _, transfer := range data.ibcTransfers {
+		if transfer.Direction == dmodels.IBCTransferDirectionOut {
+			addAccount(transfer.Sender, transfer.CreatedAt.Time)
+		} else if transfer.Status == dmodels.IBCTransferStatusReceived {
+			addAccount(transfer.Receiver, transfer.CreatedAt.Time)
+		}
+	}
 	for {
 		err := p.dao.CreateAccounts(newAccounts)
 		if err == nil {
@@ -500,6 +794,22 @@ func (p *Parser) saveNewAccounts(data data) {
 		log.Error("Parser: dao.CreateAccounts: %s", err.Error())
 		<-time.After(repeatDelay)
 	}
+	tn := time.Now()
+	dirtyAccounts := make([]dmodels.DirtyAccount, 0, len(touched))
+	for address := range touched {
+		dirtyAccounts = append(dirtyAccounts, dmodels.DirtyAccount{
+			Address:   address,
+			UpdatedAt: tn,
+		})
+	}
+	for {
+		err := p.dao.CreateDirtyAccounts(dirtyAccounts)
+		if err == nil {
+			break
+		}
+		log.Error("Parser: dao.CreateDirtyAccounts: %s", err.Error())
+		<-time.After(repeatDelay)
+	}
 }
 
 func (d *data) parseMsgSend(index int, tx Tx, data []byte) (err error) {
@@ -508,20 +818,22 @@ func (d *data) parseMsgSend(index int, tx Tx, data []byte) (err error) {
 	if err != nil {
 		return fmt.Errorf("json.Unmarshal: %s", err.Error())
 	}
-	currency, amount, err := calculateAmount(m.Amount)
+	coins, err := d.denoms.convertCoins(m.Amount)
 	if err != nil {
-		return fmt.Errorf("calculateAtomAmount: %s", err.Error())
+		return fmt.Errorf("convertCoins: %s", err.Error())
 	}
 	id := makeHash(fmt.Sprintf("%s.%d", tx.TxResponse.Hash, index))
-	d.transfers = append(d.transfers, dmodels.Transfer{
-		ID:        id,
-		TxHash:    tx.TxResponse.Hash,
-		From:      m.FromAddress,
-		To:        m.ToAddress,
-		Amount:    amount,
-		Currency:  currency,
-		CreatedAt: tx.TxResponse.Timestamp,
-	})
+	for _, c := range coins {
+		d.transfers = append(d.transfers, dmodels.Transfer{
+			ID:        d.denoms.coinID(id, c),
+			TxHash:    tx.TxResponse.Hash,
+			From:      m.FromAddress,
+			To:        m.ToAddress,
+			Amount:    c.Amount,
+			Currency:  c.Currency,
+			CreatedAt: tx.TxResponse.Timestamp,
+		})
+	}
 	return nil
 }
 
@@ -533,35 +845,39 @@ func (d *data) parseMultiSendMsg(index int, tx Tx, data []byte) (err error) {
 	}
 	for i, input := range m.Inputs {
 		id := makeHash(fmt.Sprintf("%s.%d.i.%d", tx.TxResponse.Hash, index, i))
-		currency, amount, err := calculateAmount(input.Coins)
+		coins, err := d.denoms.convertCoins(input.Coins)
 		if err != nil {
-			return fmt.Errorf("calculateAtomAmount: %s", err.Error())
+			return fmt.Errorf("convertCoins: %s", err.Error())
+		}
+		for _, c := range coins {
+			d.transfers = append(d.transfers, dmodels.Transfer{
+				ID:        d.denoms.coinID(id, c),
+				TxHash:    tx.TxResponse.Hash,
+				From:      input.Address,
+				To:        "",
+				Amount:    c.Amount,
+				Currency:  c.Currency,
+				CreatedAt: tx.TxResponse.Timestamp,
+			})
 		}
-		d.transfers = append(d.transfers, dmodels.Transfer{
-			ID:        id,
-			TxHash:    tx.TxResponse.Hash,
-			From:      input.Address,
-			To:        "",
-			Amount:    amount,
-			Currency:  currency,
-			CreatedAt: tx.TxResponse.Timestamp,
-		})
 	}
 	for i, output := range m.Outputs {
 		id := makeHash(fmt.Sprintf("%s.%d.o.%d", tx.TxResponse.Hash, index, i))
-		currency, amount, err := calculateAmount(output.Coins)
+		coins, err := d.denoms.convertCoins(output.Coins)
 		if err != nil {
-			return fmt.Errorf("calculateAtomAmount: %s", err.Error())
+			return fmt.Errorf("convertCoins: %s", err.Error())
+		}
+		for _, c := range coins {
+			d.transfers = append(d.transfers, dmodels.Transfer{
+				ID:        d.denoms.coinID(id, c),
+				TxHash:    tx.TxResponse.Hash,
+				From:      "",
+				To:        output.Address,
+				Amount:    c.Amount,
+				Currency:  c.Currency,
+				CreatedAt: tx.TxResponse.Timestamp,
+			})
 		}
-		d.transfers = append(d.transfers, dmodels.Transfer{
-			ID:        id,
-			TxHash:    tx.TxResponse.Hash,
-			From:      "",
-			To:        output.Address,
-			Amount:    amount,
-			Currency:  currency,
-			CreatedAt: tx.TxResponse.Timestamp,
-		})
 	}
 	return nil
 }
@@ -572,9 +888,9 @@ func (d *data) parseDelegateMsg(index int, tx Tx, data []byte) (err error) {
 	if err != nil {
 		return fmt.Errorf("json.Unmarshal: %s", err.Error())
 	}
-	amount, err := m.Amount.getAmount()
+	amount, err := d.denoms.convert(m.Amount)
 	if err != nil {
-		return fmt.Errorf("getAmount: %s", err.Error())
+		return fmt.Errorf("convert: %s", err.Error())
 	}
 	id := makeHash(fmt.Sprintf("%s.%d", tx.TxResponse.Hash, index))
 	d.delegations = append(d.delegations, dmodels.Delegation{
@@ -582,7 +898,7 @@ func (d *data) parseDelegateMsg(index int, tx Tx, data []byte) (err error) {
 		TxHash:    tx.TxResponse.Hash,
 		Delegator: m.DelegatorAddress,
 		Validator: m.ValidatorAddress,
-		Amount:    amount,
+		Amount:    amount.Amount,
 		CreatedAt: tx.TxResponse.Timestamp,
 	})
 	return nil
@@ -594,9 +910,9 @@ func (d *data) parseUndelegateMsg(index int, tx Tx, data []byte) (err error) {
 	if err != nil {
 		return fmt.Errorf("json.Unmarshal: %s", err.Error())
 	}
-	amount, err := m.Amount.getAmount()
+	amount, err := d.denoms.convert(m.Amount)
 	if err != nil {
-		return fmt.Errorf("getAmount: %s", err.Error())
+		return fmt.Errorf("convert: %s", err.Error())
 	}
 	id := makeHash(fmt.Sprintf("%s.%d", tx.TxResponse.Hash, index))
 	d.delegations = append(d.delegations, dmodels.Delegation{
@@ -604,9 +920,23 @@ func (d *data) parseUndelegateMsg(index int, tx Tx, data []byte) (err error) {
 		TxHash:    tx.TxResponse.Hash,
 		Delegator: m.DelegatorAddress,
 		Validator: m.ValidatorAddress,
-		Amount:    amount.Mul(decimal.NewFromFloat(-1)),
+		Amount:    amount.Amount.Mul(decimal.NewFromFloat(-1)),
 		CreatedAt: tx.TxResponse.Timestamp,
 	})
+	completionTime, err := tx.completionTime(index, "unbond")
+	if err != nil {
+		return fmt.Errorf("completionTime: %s", err.Error())
+	}
+	d.unbondings = append(d.unbondings, dmodels.Unbonding{
+		ID:             id,
+		Height:         tx.TxResponse.Height,
+		TxHash:         tx.TxResponse.Hash,
+		Delegator:      m.DelegatorAddress,
+		Validator:      m.ValidatorAddress,
+		Amount:         amount.Amount,
+		CompletionTime: completionTime,
+		CreatedAt:      tx.TxResponse.Timestamp,
+	})
 	return nil
 }
 
@@ -616,9 +946,9 @@ func (d *data) parseBeginRedelegateMsg(index int, tx Tx, data []byte) (err error
 	if err != nil {
 		return fmt.Errorf("json.Unmarshal: %s", err.Error())
 	}
-	amount, err := m.Amount.getAmount()
+	amount, err := d.denoms.convert(m.Amount)
 	if err != nil {
-		return fmt.Errorf("getAmount: %s", err.Error())
+		return fmt.Errorf("convert: %s", err.Error())
 	}
 	id := makeHash(fmt.Sprintf("%s.%d.s", tx.TxResponse.Hash, index))
 	d.delegations = append(d.delegations, dmodels.Delegation{
@@ -626,7 +956,7 @@ func (d *data) parseBeginRedelegateMsg(index int, tx Tx, data []byte) (err error
 		TxHash:    tx.TxResponse.Hash,
 		Delegator: m.DelegatorAddress,
 		Validator: m.ValidatorSrcAddress,
-		Amount:    amount.Mul(decimal.NewFromFloat(-1)),
+		Amount:    amount.Amount.Mul(decimal.NewFromFloat(-1)),
 		CreatedAt: tx.TxResponse.Timestamp,
 	})
 	id = makeHash(fmt.Sprintf("%s.%d.d", tx.TxResponse.Hash, index))
@@ -635,9 +965,24 @@ func (d *data) parseBeginRedelegateMsg(index int, tx Tx, data []byte) (err error
 		TxHash:    tx.TxResponse.Hash,
 		Delegator: m.DelegatorAddress,
 		Validator: m.ValidatorDstAddress,
-		Amount:    amount,
+		Amount:    amount.Amount,
 		CreatedAt: tx.TxResponse.Timestamp,
 	})
+	completionTime, err := tx.completionTime(index, "redelegate")
+	if err != nil {
+		return fmt.Errorf("completionTime: %s", err.Error())
+	}
+	d.redelegations = append(d.redelegations, dmodels.Redelegation{
+		ID:             makeHash(fmt.Sprintf("%s.%d", tx.TxResponse.Hash, index)),
+		Height:         tx.TxResponse.Height,
+		TxHash:         tx.TxResponse.Hash,
+		Delegator:      m.DelegatorAddress,
+		ValidatorSrc:   m.ValidatorSrcAddress,
+		ValidatorDst:   m.ValidatorDstAddress,
+		Amount:         amount.Amount,
+		CompletionTime: completionTime,
+		CreatedAt:      tx.TxResponse.Timestamp,
+	})
 	return nil
 }
 
@@ -648,14 +993,14 @@ func (d *data) parseWithdrawDelegationRewardMsg(index int, tx Tx, data []byte) (
 		return fmt.Errorf("json.Unmarshal: %s", err.Error())
 	}
 
-	mp := make(map[string]decimal.Decimal)
+	mp := make(map[string][]Amount)
 	for _, log := range tx.TxResponse.Logs {
 		for _, event := range log.Events {
 			if event.Type == "withdraw_rewards" {
 				for i := 0; i < len(event.Attributes); i += 2 {
-					amount, err := strToAmount(event.Attributes[i].Value)
+					amount, err := parseCoins(event.Attributes[i].Value)
 					if err != nil {
-						return fmt.Errorf("strToAmount: %s", err.Error())
+						return fmt.Errorf("parseCoins: %s", err.Error())
 					}
 					if event.Attributes[i+1].Key != "validator" {
 						return fmt.Errorf("not found validator in events")
@@ -671,16 +1016,23 @@ func (d *data) parseWithdrawDelegationRewardMsg(index int, tx Tx, data []byte) (
 	if !ok {
 		return fmt.Errorf("not found validator %s in map", m.ValidatorAddress)
 	}
+	coins, err := d.rewardCoins(amount)
+	if err != nil {
+		return fmt.Errorf("rewardCoins: %s", err.Error())
+	}
 
 	id := makeHash(fmt.Sprintf("%s.%d", tx.TxResponse.Hash, index))
-	d.delegatorRewards = append(d.delegatorRewards, dmodels.DelegatorReward{
-		ID:        id,
-		TxHash:    tx.TxResponse.Hash,
-		Delegator: m.DelegatorAddress,
-		Validator: m.ValidatorAddress,
-		Amount:    amount,
-		CreatedAt: tx.TxResponse.Timestamp,
-	})
+	for _, c := range coins {
+		d.delegatorRewards = append(d.delegatorRewards, dmodels.DelegatorReward{
+			ID:        d.denoms.coinID(id, c),
+			TxHash:    tx.TxResponse.Hash,
+			Delegator: m.DelegatorAddress,
+			Validator: m.ValidatorAddress,
+			Amount:    c.Amount,
+			Currency:  c.Currency,
+			CreatedAt: tx.TxResponse.Timestamp,
+		})
+	}
 	return nil
 }
 
@@ -708,13 +1060,13 @@ func (d *data) parseSubmitProposalMsg(index int, tx Tx, data []byte) (err error)
 	if id == 0 {
 		return fmt.Errorf("not found proposal_id")
 	}
-	amount, err := calculateAtomAmount(m.Content.Value.Amount)
+	amount, err := d.denoms.baseAmount(m.Content.Value.Amount)
 	if err != nil {
-		return fmt.Errorf("calculateAtomAmount: %s", err.Error())
+		return fmt.Errorf("baseAmount: %s", err.Error())
 	}
-	initDeposit, err := calculateAtomAmount(m.InitialDeposit)
+	initDeposit, err := d.denoms.baseAmount(m.InitialDeposit)
 	if err != nil {
-		return fmt.Errorf("calculateAtomAmount: %s", err.Error())
+		return fmt.Errorf("baseAmount: %s", err.Error())
 	}
 	d.proposals = append(d.proposals, dmodels.HistoryProposal{
 		ID:          id,
@@ -747,7 +1099,7 @@ func (d *data) parseVoteMsg(index int, tx Tx, data []byte) (err error) {
 	case "VOTE_OPTION_NO_WITH_VETO":
 		option = "NoWithVeto"
 	default:
-		return fmt.Errorf("unknown type of option: %d", m.Option)
+		return fmt.Errorf("unknown type of option: %s", m.Option)
 	}
 	id := makeHash(fmt.Sprintf("%s.%d.s", tx.TxResponse.Hash, index))
 	d.proposalVotes = append(d.proposalVotes, dmodels.ProposalVote{
@@ -767,23 +1119,23 @@ func (d *data) parseDepositMsg(index int, tx Tx, data []byte) (err error) {
 	if err != nil {
 		return fmt.Errorf("json.Unmarshal: %s", err.Error())
 	}
-	amount := decimal.Zero
-	for _, a := range m.Amount {
-		amt, err := a.getAmount()
-		if err != nil {
-			return fmt.Errorf("getAmount: %s", err.Error())
-		}
-		amount = amount.Add(amt)
+	coins, err := d.rewardCoins(m.Amount)
+	if err != nil {
+		return fmt.Errorf("rewardCoins: %s", err.Error())
 	}
 
 	id := makeHash(fmt.Sprintf("%s.%d.s", tx.TxResponse.Hash, index))
-	d.proposalDeposits = append(d.proposalDeposits, dmodels.ProposalDeposit{
-		ID:         id,
-		ProposalID: m.ProposalID,
-		Depositor:  m.Depositor,
-		Amount:     amount,
-		CreatedAt:  dmodels.NewTime(tx.TxResponse.Timestamp),
-	})
+	for _, c := range coins {
+		d.proposalDeposits = append(d.proposalDeposits, dmodels.ProposalDeposit{
+			ID:         d.denoms.coinID(id, c),
+			ProposalID: m.ProposalID,
+			Depositor:  m.Depositor,
+			TxHash:     tx.TxResponse.Hash,
+			Amount:     c.Amount,
+			Currency:   c.Currency,
+			CreatedAt:  dmodels.NewTime(tx.TxResponse.Timestamp),
+		})
+	}
 	return nil
 }
 
@@ -793,16 +1145,16 @@ func (d *data) parseWithdrawValidatorCommissionMsg(index int, tx Tx, data []byte
 	if err != nil {
 		return fmt.Errorf("json.Unmarshal: %s", err.Error())
 	}
-	var amount decimal.Decimal
+	var amount []Amount
 	found := false
 	for _, log := range tx.TxResponse.Logs {
 		for _, event := range log.Events {
 			if event.Type == "withdraw_commission" {
 				for _, att := range event.Attributes {
 					if att.Key == "amount" {
-						amount, err = strToAmount(att.Value)
+						amount, err = parseCoins(att.Value)
 						if err != nil {
-							return fmt.Errorf("strToAmount: %s", err.Error())
+							return fmt.Errorf("parseCoins: %s", err.Error())
 						}
 						found = true
 					}
@@ -813,14 +1165,21 @@ func (d *data) parseWithdrawValidatorCommissionMsg(index int, tx Tx, data []byte
 	if !found {
 		return fmt.Errorf("amount not found")
 	}
+	coins, err := d.rewardCoins(amount)
+	if err != nil {
+		return fmt.Errorf("rewardCoins: %s", err.Error())
+	}
 	id := makeHash(fmt.Sprintf("%s.%d", tx.TxResponse.Hash, index))
-	d.validatorRewards = append(d.validatorRewards, dmodels.ValidatorReward{
-		TxHash:    tx.TxResponse.Hash,
-		ID:        id,
-		Address:   m.ValidatorAddress,
-		Amount:    amount,
-		CreatedAt: tx.TxResponse.Timestamp,
-	})
+	for _, c := range coins {
+		d.validatorRewards = append(d.validatorRewards, dmodels.ValidatorReward{
+			TxHash:    tx.TxResponse.Hash,
+			ID:        d.denoms.coinID(id, c),
+			Address:   m.ValidatorAddress,
+			Amount:    c.Amount,
+			Currency:  c.Currency,
+			CreatedAt: tx.TxResponse.Timestamp,
+		})
+	}
 	return nil
 }
 
@@ -834,69 +1193,337 @@ func (d *data) parseUnjailMsg(index int, tx Tx, data []byte) (err error) {
 	d.jailers = append(d.jailers, dmodels.Jailer{
 		ID:        id,
 		Address:   m.ValidatorAddr,
+		TxHash:    tx.TxResponse.Hash,
 		CreatedAt: tx.TxResponse.Timestamp,
 	})
 	return nil
 }
 
-func calculateAtomAmount(amountItems []Amount) (decimal.Decimal, error) {
-	volume := decimal.Zero
-	for _, item := range amountItems {
-		if item.Denom == "" && item.Amount.IsZero() { // example height=1245781
-			break
+func (d *data) parseCreateValidatorMsg(index int, tx Tx, data []byte) (err error) {
+	var m MsgCreateValidator
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal: %s", err.Error())
+	}
+	selfBond, err := d.denoms.convert(m.Value)
+	if err != nil {
+		return fmt.Errorf("convert: %s", err.Error())
+	}
+	minSelfDelegation, err := d.denoms.convert(Amount{Denom: d.denoms.base, Amount: m.MinSelfDelegation})
+	if err != nil {
+		return fmt.Errorf("convert: %s", err.Error())
+	}
+	consAddress, err := helpers.GetHexAddressFromBase64PK(m.Pubkey.Key)
+	if err != nil {
+		return fmt.Errorf("helpers.GetHexAddressFromBase64PK: %s", err.Error())
+	}
+	id := makeHash(fmt.Sprintf("%s.%d", tx.TxResponse.Hash, index))
+	d.validatorEvents = append(d.validatorEvents, validatorEvent{event: dmodels.ValidatorEvent{
+		ID:                id,
+		TxHash:            tx.TxResponse.Hash,
+		Height:            tx.TxResponse.Height,
+		Type:              dmodels.ValidatorEventCreate,
+		OperatorAddress:   m.ValidatorAddress,
+		Address:           m.DelegatorAddress,
+		ConsAddress:       consAddress,
+		ConsPubKey:        m.Pubkey.Key,
+		Moniker:           m.Description.Moniker,
+		Identity:          m.Description.Identity,
+		Website:           m.Description.Website,
+		Details:           m.Description.Details,
+		CommissionRate:    m.Commission.Rate,
+		MaxRate:           m.Commission.MaxRate,
+		MaxChangeRate:     m.Commission.MaxChangeRate,
+		MinSelfDelegation: minSelfDelegation.Amount,
+		SelfBond:          selfBond.Amount,
+		CreatedAt:         dmodels.NewTime(tx.TxResponse.Timestamp),
+	}})
+	// the self-bond is the first delegation to the validator
+	d.delegations = append(d.delegations, dmodels.Delegation{
+		ID:        id,
+		TxHash:    tx.TxResponse.Hash,
+		Delegator: m.DelegatorAddress,
+		Validator: m.ValidatorAddress,
+		Amount:    selfBond.Amount,
+		CreatedAt: tx.TxResponse.Timestamp,
+	})
+	return nil
+}
+
+func (d *data) parseEditValidatorMsg(index int, tx Tx, data []byte) (err error) {
+	var m MsgEditValidator
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal: %s", err.Error())
+	}
+	if m.MinSelfDelegation != "" {
+		amount, err := decimal.NewFromString(m.MinSelfDelegation)
+		if err != nil {
+			return fmt.Errorf("min_self_delegation: %s", err.Error())
+		}
+		converted, err := d.denoms.convert(Amount{Denom: d.denoms.base, Amount: amount})
+		if err != nil {
+			return fmt.Errorf("convert: %s", err.Error())
+		}
+		m.MinSelfDelegation = converted.Amount.String()
+	}
+	d.validatorEvents = append(d.validatorEvents, validatorEvent{
+		event: dmodels.ValidatorEvent{
+			ID:              makeHash(fmt.Sprintf("%s.%d", tx.TxResponse.Hash, index)),
+			TxHash:          tx.TxResponse.Hash,
+			Height:          tx.TxResponse.Height,
+			Type:            dmodels.ValidatorEventEdit,
+			OperatorAddress: m.ValidatorAddress,
+			CreatedAt:       dmodels.NewTime(tx.TxResponse.Timestamp),
+		},
+		edit: &m,
+	})
+	return nil
+}
+
+func (d *data) parseIBCTransferMsg(index int, tx Tx, data []byte) (err error) {
+	var m MsgTransfer
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal: %s", err.Error())
+	}
+	event, ok := tx.findEvent(index, "send_packet")
+	if !ok {
+		return fmt.Errorf("not found send_packet event")
+	}
+	var packetData FungibleTokenPacketData
+	err = json.Unmarshal([]byte(event.attribute("packet_data")), &packetData)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal: %s", err.Error())
+	}
+	sequence, err := strconv.ParseUint(event.attribute("packet_sequence"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("strconv.ParseUint: %s", err.Error())
+	}
+	packet := Packet{
+		Sequence:           sequence,
+		SourcePort:         event.attribute("packet_src_port"),
+		SourceChannel:      event.attribute("packet_src_channel"),
+		DestinationPort:    event.attribute("packet_dst_port"),
+		DestinationChannel: event.attribute("packet_dst_channel"),
+	}
+	transfer := d.newIBCTransfer(packet, packetData, dmodels.IBCTransferDirectionOut)
+	transfer.TxHash = tx.TxResponse.Hash
+	transfer.Status = dmodels.IBCTransferStatusPending
+	transfer.CreatedAt = dmodels.NewTime(tx.TxResponse.Timestamp)
+	transfer.UpdatedAt = transfer.CreatedAt
+	d.ibcTransfers = append(d.ibcTransfers, transfer)
+	return nil
+}
+
+func (d *data) parseIBCRecvPacketMsg(index int, tx Tx, data []byte) (err error) {
+	var m MsgRecvPacket
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal: %s", err.Error())
+	}
+	if m.Packet.DestinationPort != ibcTransferPort {
+		return nil
+	}
+	var packetData FungibleTokenPacketData
+	err = json.Unmarshal(m.Packet.Data, &packetData)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal: %s", err.Error())
+	}
+	transfer := d.newIBCTransfer(m.Packet, packetData, dmodels.IBCTransferDirectionIn)
+	transfer.TxHash = tx.TxResponse.Hash
+	transfer.Status = dmodels.IBCTransferStatusReceived
+	if event, ok := tx.findEvent(index, "write_acknowledgement"); ok {
+		var ack Acknowledgement
+		err = json.Unmarshal([]byte(event.attribute("packet_ack")), &ack)
+		if err != nil {
+			return fmt.Errorf("json.Unmarshal: %s", err.Error())
+		}
+		if ack.Error != "" {
+			transfer.Status = dmodels.IBCTransferStatusFailed
+		}
+	}
+	transfer.CreatedAt = dmodels.NewTime(tx.TxResponse.Timestamp)
+	transfer.UpdatedAt = transfer.CreatedAt
+	d.ibcTransfers = append(d.ibcTransfers, transfer)
+	return nil
+}
+
+func (d *data) parseIBCAcknowledgementMsg(index int, tx Tx, data []byte) (err error) {
+	var m MsgAcknowledgement
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal: %s", err.Error())
+	}
+	if m.Packet.SourcePort != ibcTransferPort {
+		return nil
+	}
+	var ack Acknowledgement
+	err = json.Unmarshal(m.Acknowledgement, &ack)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal: %s", err.Error())
+	}
+	status := dmodels.IBCTransferStatusAcknowledged
+	if ack.Error != "" {
+		status = dmodels.IBCTransferStatusFailed
+	}
+	return d.closeIBCTransfer(tx, m.Packet, status)
+}
+
+func (d *data) parseIBCTimeoutMsg(index int, tx Tx, data []byte) (err error) {
+	var m MsgTimeout
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal: %s", err.Error())
+	}
+	if m.Packet.SourcePort != ibcTransferPort {
+		return nil
+	}
+	return d.closeIBCTransfer(tx, m.Packet, dmodels.IBCTransferStatusTimeout)
+}
+
+// closeIBCTransfer stores the final state of an outgoing transfer. The tx hash and
+// creation time are replaced by the original ones in matchIBCTransfers.
+func (d *data) closeIBCTransfer(tx Tx, packet Packet, status string) error {
+	var packetData FungibleTokenPacketData
+	err := json.Unmarshal(packet.Data, &packetData)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal: %s", err.Error())
+	}
+	transfer := d.newIBCTransfer(packet, packetData, dmodels.IBCTransferDirectionOut)
+	transfer.TxHash = tx.TxResponse.Hash
+	transfer.AckTxHash = tx.TxResponse.Hash
+	transfer.Status = status
+	transfer.CreatedAt = dmodels.NewTime(tx.TxResponse.Timestamp)
+	transfer.UpdatedAt = transfer.CreatedAt
+	d.ibcTransfers = append(d.ibcTransfers, transfer)
+	return nil
+}
+
+func (d *data) newIBCTransfer(packet Packet, packetData FungibleTokenPacketData, direction string) dmodels.IBCTransfer {
+	denom := packetData.Denom
+	if direction == dmodels.IBCTransferDirectionIn {
+		prefix := fmt.Sprintf("%s/%s/", packet.SourcePort, packet.SourceChannel)
+		if strings.HasPrefix(denom, prefix) {
+			denom = strings.TrimPrefix(denom, prefix)
+		} else {
+			denom = fmt.Sprintf("%s/%s/%s", packet.DestinationPort, packet.DestinationChannel, denom)
 		}
-		if item.Denom != "uatom" {
-			return volume, fmt.Errorf("unknown demon (currency): %s", item.Denom)
+	}
+	c := d.denoms.getByTrace(denom).coin(packetData.Amount)
+	return dmodels.IBCTransfer{
+		ID: makeHash(fmt.Sprintf("%s.%s.%s.%s.%d", packet.SourcePort, packet.SourceChannel,
+			packet.DestinationPort, packet.DestinationChannel, packet.Sequence)),
+		Direction:          direction,
+		SourcePort:         packet.SourcePort,
+		SourceChannel:      packet.SourceChannel,
+		DestinationPort:    packet.DestinationPort,
+		DestinationChannel: packet.DestinationChannel,
+		Sequence:           packet.Sequence,
+		Sender:             packetData.Sender,
+		Receiver:           packetData.Receiver,
+		Denom:              c.Currency,
+		Amount:             c.Amount,
+	}
+}
+
+// messageTypes returns the type url of every message of the transaction.
+func (tx Tx) messageTypes() (types []string, err error) {
+	types = make([]string, len(tx.Tx.Body.Messages))
+	for i, msg := range tx.Tx.Body.Messages {
+		var baseMsg BaseMsg
+		err = json.Unmarshal(msg, &baseMsg)
+		if err != nil {
+			return nil, fmt.Errorf("json.Unmarshal: %s", err.Error())
 		}
-		volume = volume.Add(item.Amount)
+		types[i] = baseMsg.Type
 	}
-	volume = volume.Div(precisionDiv)
-	return volume, nil
+	return types, nil
 }
 
-func calculateAmount(amountItems []Amount) (string, decimal.Decimal, error) {
-	volume := decimal.Zero
-	var lastCurrency string
-	for _, item := range amountItems {
-		if item.Denom == "" {
-			return lastCurrency, volume, errors.New("empty denom")
+// txSigners returns the account addresses of the signer public keys, the multisig signers are skipped.
+func (p *Parser) txSigners(tx Tx) (signers []string) {
+	for _, info := range tx.Tx.AuthInfo.SignerInfos {
+		if info.PublicKey.Key == "" {
+			continue
 		}
-		if lastCurrency == "" {
-			lastCurrency = item.Denom
-		} else if item.Denom != lastCurrency {
-			return lastCurrency, volume, fmt.Errorf("different currencies: %s, %s", lastCurrency, item.Denom)
+		address, err := helpers.GetAddressFromPubKey(info.PublicKey.Type, info.PublicKey.Key, p.cfg.Chain.AccountPrefix)
+		if err != nil {
+			log.Warn("Parser: tx %s: helpers.GetAddressFromPubKey: %s", tx.TxResponse.Hash, err.Error())
+			continue
 		}
-		volume = volume.Add(item.Amount)
+		signers = append(signers, address)
 	}
-	if lastCurrency == "uatom" {
-		volume = volume.Div(precisionDiv)
-		lastCurrency = "atom"
+	return signers
+}
+
+func (tx Tx) findEvent(msgIndex int, eventType string) (event TxEvent, found bool) {
+	for _, l := range tx.TxResponse.Logs {
+		if l.MsgIndex != msgIndex {
+			continue
+		}
+		for _, e := range l.Events {
+			if e.Type == eventType {
+				return e, true
+			}
+		}
 	}
-	return lastCurrency, volume, nil
+	return event, false
 }
 
-func (a Amount) getAmount() (decimal.Decimal, error) {
-	if a.Denom == "" && a.Amount.IsZero() {
-		return decimal.Zero, nil
+// completionTime returns the time the unbonding or the redelegation of the message matures at.
+func (tx Tx) completionTime(msgIndex int, eventType string) (t time.Time, err error) {
+	event, ok := tx.findEvent(msgIndex, eventType)
+	if !ok {
+		return t, fmt.Errorf("not found %s event", eventType)
 	}
-	if a.Denom != "uatom" {
-		return decimal.Zero, fmt.Errorf("unknown demon (currency): %s", a.Denom)
+	t, err = time.Parse(time.RFC3339, event.attribute("completion_time"))
+	if err != nil {
+		return t, fmt.Errorf("time.Parse: %s", err.Error())
 	}
-	a.Amount = a.Amount.Div(precisionDiv)
-	return a.Amount, nil
+	return t, nil
 }
 
-func strToAmount(str string) (decimal.Decimal, error) {
-	if str == "" {
-		return decimal.Zero, nil
+func (e TxEvent) attribute(key string) string {
+	for _, att := range e.Attributes {
+		if att.Key == key {
+			return att.Value
+		}
 	}
-	val := strings.TrimSuffix(str, "uatom")
-	amount, err := decimal.NewFromString(val)
+	return ""
+}
+
+// parseFee stores each fee coin and returns the fee in the staking denom.
+func (d *data) parseFee(tx Tx) (fee decimal.Decimal, err error) {
+	coins, err := d.denoms.convertCoins(tx.Tx.AuthInfo.Fee.Amount)
 	if err != nil {
-		return amount, fmt.Errorf("decimal.NewFromString: %s", err.Error())
+		return fee, fmt.Errorf("convertCoins: %s", err.Error())
+	}
+	for _, c := range coins {
+		if c.Currency == d.denoms.baseCurrency() {
+			fee = c.Amount
+		}
+		d.fees = append(d.fees, dmodels.TransactionFee{
+			ID:        makeHash(fmt.Sprintf("%s.%s", tx.TxResponse.Hash, c.Currency)),
+			TxHash:    tx.TxResponse.Hash,
+			Currency:  c.Currency,
+			Amount:    c.Amount,
+			CreatedAt: tx.TxResponse.Timestamp,
+		})
+	}
+	return fee, nil
+}
+
+// rewardCoins converts coins and keeps a zero row in the staking denom for empty amounts.
+func (d *data) rewardCoins(items []Amount) ([]coin, error) {
+	coins, err := d.denoms.convertCoins(items)
+	if err != nil {
+		return nil, err
+	}
+	if len(coins) == 0 {
+		coins = append(coins, coin{Currency: d.denoms.baseCurrency(), Amount: decimal.Zero})
 	}
-	amount = amount.Div(precisionDiv)
-	return amount, nil
+	return coins, nil
 }
 
 func makeHash(str string) string {
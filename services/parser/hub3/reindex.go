@@ -0,0 +1,96 @@
+package hub3
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kwanifi/numiscan-api/dmodels"
+)
+
+// Reindex fetches the blocks between from and to (inclusive) again and saves them to the given tables,
+// or to all of them when tables is empty. Already stored rows are replaced by their ID,
+// the height of the parser is not changed.
+func (p *Parser) Reindex(from uint64, to uint64, tables []string) error {
+	if from == 0 || to < from {
+		return fmt.Errorf("invalid range: %d - %d", from, to)
+	}
+	known := make(map[string]bool)
+	for _, table := range p.tables() {
+		known[table] = true
+	}
+	filter := make(map[string]bool)
+	for _, table := range tables {
+		if !known[table] {
+			return fmt.Errorf("unknown table %s, available: %s", table, strings.Join(p.tables(), ", "))
+		}
+		filter[table] = true
+	}
+	latestBlock, err := p.api.GetLatestBlock()
+	if err != nil {
+		return fmt.Errorf("api.GetLatestBlock: %s", err.Error())
+	}
+	if to > latestBlock.Block.Header.Height {
+		return fmt.Errorf("height %d is above the latest block %d", to, latestBlock.Block.Header.Height)
+	}
+	if len(filter) == 0 || filter[dmodels.AccountsTable] {
+		p.setAccounts()
+	}
+
+	for i := uint64(0); i < p.cfg.Parser.Fetchers; i++ {
+		go p.runFetcher()
+	}
+	go func() {
+		for height := from; height <= to; height++ {
+			select {
+			case <-p.ctx.Done():
+				return
+			case p.fetcherCh <- height:
+			}
+		}
+	}()
+
+	total := to - from + 1
+	started := time.Now()
+	pending := make(map[uint64]data)
+	next := from
+	for next <= to {
+		select {
+		case <-p.ctx.Done():
+			return fmt.Errorf("stopped at height %d", next)
+		case d := <-p.saverCh:
+			pending[d.height] = d
+		}
+		var batch data
+		var count uint64
+		for ; count < p.cfg.Parser.Batch && next+count <= to; count++ {
+			item, ok := pending[next+count]
+			if !ok {
+				break
+			}
+			batch.merge(item)
+		}
+		// wait for a full batch, except the last one
+		if count == 0 || (count < p.cfg.Parser.Batch && next+count <= to) {
+			continue
+		}
+		p.save(batch, filter)
+		for i := uint64(0); i < count; i++ {
+			delete(pending, next+i)
+		}
+		next += count
+		done := next - from
+		fmt.Printf("reindex: %d/%d blocks (%.1f%%), height %d, %s\n",
+			done, total, float64(done)*100/float64(total), next-1, time.Since(started).Truncate(time.Second))
+	}
+	return nil
+}
+
+// tables returns the names of the tables written by the parser.
+func (p *Parser) tables() []string {
+	var tables []string
+	for _, s := range p.savers() {
+		tables = append(tables, s.table)
+	}
+	return append(tables, dmodels.AccountsTable)
+}
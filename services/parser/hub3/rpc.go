@@ -0,0 +1,272 @@
+package hub3
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/bytes"
+)
+
+const rpcPerPage = 100
+
+type (
+	// RPCAPI fetches blocks from the tendermint RPC of the node,
+	// all transactions of a height are taken with a single tx_search call.
+	RPCAPI struct {
+		address string
+		client  *http.Client
+		codec   *txCodec
+	}
+
+	rpcResponse struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+			Data    string `json:"data"`
+		} `json:"error"`
+	}
+	rpcTxResult struct {
+		Code      int64  `json:"code"`
+		Log       string `json:"log"`
+		GasWanted uint64 `json:"gas_wanted,string"`
+		GasUsed   uint64 `json:"gas_used,string"`
+	}
+	rpcTx struct {
+		Hash     string      `json:"hash"`
+		Height   uint64      `json:"height,string"`
+		TxResult rpcTxResult `json:"tx_result"`
+		Tx       []byte      `json:"tx"`
+	}
+	rpcTxSearch struct {
+		Txs        []rpcTx `json:"txs"`
+		TotalCount uint64  `json:"total_count,string"`
+	}
+	rpcBlockResults struct {
+		TxsResults []rpcTxResult `json:"txs_results"`
+		BlockResults
+	}
+	rpcValidators struct {
+		Validators []struct {
+			Address string `json:"address"`
+			PubKey  struct {
+				Type  string `json:"type"`
+				Value string `json:"value"`
+			} `json:"pub_key"`
+			VotingPower decimal.Decimal `json:"voting_power"`
+		} `json:"validators"`
+		Total uint64 `json:"total,string"`
+	}
+)
+
+func NewRPCAPI(address string) *RPCAPI {
+	return &RPCAPI{
+		address: strings.TrimSuffix(address, "/"),
+		client: &http.Client{
+			Timeout: time.Minute,
+		},
+		codec: newTxCodec(),
+	}
+}
+
+func (api *RPCAPI) GetLatestBlock() (block Block, err error) {
+	err = api.get("block", nil, &block)
+	return block, err
+}
+
+func (api *RPCAPI) GetBlock(height uint64) (block Block, err error) {
+	err = api.get("block", map[string]string{"height": strconv.FormatUint(height, 10)}, &block)
+	return block, err
+}
+
+func (api *RPCAPI) GetTx(hash string) (tx Tx, err error) {
+	var item rpcTx
+	err = api.get("tx", map[string]string{"hash": "0x" + hash}, &item)
+	if err != nil {
+		return tx, err
+	}
+	block, err := api.GetBlock(item.Height)
+	if err != nil {
+		return tx, fmt.Errorf("GetBlock: %s", err.Error())
+	}
+	return api.makeTx(item.Hash, item.Height, item.TxResult, item.Tx, block.Block.Header.Time)
+}
+
+// GetBlockTxs returns the transactions of the block in the order of the block.
+// When tx_search fails or misses transactions, as with the tx indexer of the node disabled,
+// the results are taken from block_results.
+func (api *RPCAPI) GetBlockTxs(block Block) (txs []Tx, err error) {
+	height := block.Block.Header.Height
+	if len(block.Block.Data.Txs) == 0 {
+		return nil, nil
+	}
+	var items []rpcTx
+	for page := 1; ; page++ {
+		var result rpcTxSearch
+		err = api.get("tx_search", map[string]string{
+			"query":    fmt.Sprintf(`"tx.height=%d"`, height),
+			"page":     strconv.Itoa(page),
+			"per_page": strconv.Itoa(rpcPerPage),
+			"order_by": `"asc"`,
+		}, &result)
+		if err != nil {
+			// the node with the tx indexer disabled refuses tx_search, the transactions are taken from the block then
+			return api.getBlockResultsTxs(block)
+		}
+		items = append(items, result.Txs...)
+		if len(result.Txs) == 0 || uint64(len(items)) >= result.TotalCount {
+			break
+		}
+	}
+	if len(items) != len(block.Block.Data.Txs) {
+		return api.getBlockResultsTxs(block)
+	}
+	for _, item := range items {
+		tx, err := api.makeTx(item.Hash, height, item.TxResult, item.Tx, block.Block.Header.Time)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+func (api *RPCAPI) getBlockResultsTxs(block Block) (txs []Tx, err error) {
+	height := block.Block.Header.Height
+	var results rpcBlockResults
+	err = api.get("block_results", map[string]string{"height": strconv.FormatUint(height, 10)}, &results)
+	if err != nil {
+		return nil, fmt.Errorf("block_results: %s", err.Error())
+	}
+	if len(results.TxsResults) != len(block.Block.Data.Txs) {
+		return nil, fmt.Errorf("block_results: got %d results for %d txs", len(results.TxsResults), len(block.Block.Data.Txs))
+	}
+	for i, txData := range block.Block.Data.Txs {
+		raw, err := base64.StdEncoding.DecodeString(txData)
+		if err != nil {
+			return nil, fmt.Errorf("base64.DecodeString: %s", err.Error())
+		}
+		hash := bytes.HexBytes(crypto.Sha256(raw)).String()
+		tx, err := api.makeTx(hash, height, results.TxsResults[i], raw, block.Block.Header.Time)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+// makeTx builds the LCD shaped Tx. The logs of successful transactions are kept by the node as json in the log field.
+func (api *RPCAPI) makeTx(hash string, height uint64, result rpcTxResult, raw []byte, timestamp time.Time) (tx Tx, err error) {
+	tx.TxResponse.Hash = hash
+	tx.TxResponse.Height = height
+	tx.TxResponse.Code = result.Code
+	tx.TxResponse.RawLog = result.Log
+	tx.TxResponse.GasWanted = result.GasWanted
+	tx.TxResponse.GasUsed = result.GasUsed
+	tx.TxResponse.Timestamp = timestamp
+	if result.Code == 0 {
+		err = json.Unmarshal([]byte(result.Log), &tx.TxResponse.Logs)
+		if err != nil {
+			return tx, fmt.Errorf("tx %s: logs: %s", hash, err.Error())
+		}
+	}
+	err = api.codec.decode(raw, &tx)
+	if err != nil {
+		return tx, fmt.Errorf("tx %s: decode: %s", hash, err.Error())
+	}
+	return tx, nil
+}
+
+// GetBlockResults returns the begin and end block events of the height.
+func (api *RPCAPI) GetBlockResults(height uint64) (results BlockResults, err error) {
+	var item rpcBlockResults
+	err = api.get("block_results", map[string]string{"height": strconv.FormatUint(height, 10)}, &item)
+	if err != nil {
+		return results, err
+	}
+	return item.BlockResults, nil
+}
+
+func (api *RPCAPI) GetValidatorset(height uint64) (set Validatorsets, err error) {
+	for page := 1; ; page++ {
+		var result rpcValidators
+		err = api.get("validators", map[string]string{
+			"height":   strconv.FormatUint(height, 10),
+			"page":     strconv.Itoa(page),
+			"per_page": strconv.Itoa(rpcPerPage),
+		}, &result)
+		if err != nil {
+			return set, err
+		}
+		for _, v := range result.Validators {
+			var item ValidatorsetItem
+			item.Address = v.Address
+			item.PubKey.Type = v.PubKey.Type
+			item.PubKey.Key = v.PubKey.Value
+			item.VotingPower = v.VotingPower
+			set.Validators = append(set.Validators, item)
+		}
+		if len(result.Validators) == 0 || uint64(len(set.Validators)) >= result.Total {
+			break
+		}
+	}
+	return set, nil
+}
+
+// lastValidatorsPage tells whether the page of the validator set is the last one. The total is used when the node returns it,
+// a short page ends the set otherwise.
+func lastValidatorsPage(pageSize int, fetched int, total uint64) bool {
+	if total != 0 {
+		return uint64(fetched) >= total || pageSize == 0
+	}
+	return pageSize < rpcPerPage
+}
+
+// pageOutOfRange tells whether tendermint rejected the page as past the end of the validator set,
+// it happens after a full last page when the total is not known.
+func pageOutOfRange(err error) bool {
+	return strings.Contains(err.Error(), "page should be within")
+}
+
+func (api *RPCAPI) get(endpoint string, params map[string]string, result interface{}) error {
+	fullURL := fmt.Sprintf("%s/%s", api.address, endpoint)
+	if len(params) != 0 {
+		values := url.Values{}
+		for key, value := range params {
+			values.Add(key, value)
+		}
+		fullURL = fmt.Sprintf("%s?%s", fullURL, values.Encode())
+	}
+	resp, err := api.client.Get(fullURL)
+	if err != nil {
+		return fmt.Errorf("client.Get: %s", err.Error())
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("ioutil.ReadAll: %s", err.Error())
+	}
+	var response rpcResponse
+	err = json.Unmarshal(data, &response)
+	if err != nil {
+		return fmt.Errorf("bad status: %d, json.Unmarshal: %s", resp.StatusCode, err.Error())
+	}
+	if response.Error != nil {
+		return fmt.Errorf("rpc error: %d, %s %s", response.Error.Code, response.Error.Message, response.Error.Data)
+	}
+	err = json.Unmarshal(response.Result, result)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal: %s", err.Error())
+	}
+	return nil
+}
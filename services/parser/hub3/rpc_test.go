@@ -0,0 +1,49 @@
+package hub3
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+)
+
+// eventAttribute returns the JSON of a block event attribute with the key and the value encoded as the RPC does.
+func eventAttribute(key, value string) string {
+	return fmt.Sprintf(`{"key":"%s","value":"%s"}`,
+		base64.StdEncoding.EncodeToString([]byte(key)), base64.StdEncoding.EncodeToString([]byte(value)))
+}
+
+func TestGetBlockTxsWithoutIndexer(t *testing.T) {
+	body, _ := (&txtypes.TxBody{Memo: "memo"}).Marshal()
+	authInfo, _ := (&txtypes.AuthInfo{}).Marshal()
+	raw, _ := (&txtypes.TxRaw{BodyBytes: body, AuthInfoBytes: authInfo}).Marshal()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tx_search":
+			fmt.Fprint(w, `{"jsonrpc":"2.0","id":-1,"error":{"code":-32603,"message":"Internal error","data":"transaction indexing is disabled"}}`)
+		case "/block_results":
+			fmt.Fprint(w, `{"jsonrpc":"2.0","id":-1,"result":{"txs_results":[{"code":1,"log":"out of gas","gas_wanted":"100","gas_used":"200"}]}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var block Block
+	block.Block.Header.Height = 100
+	block.Block.Data.Txs = []string{base64.StdEncoding.EncodeToString(raw)}
+	txs, err := NewRPCAPI(server.URL).GetBlockTxs(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 1 {
+		t.Fatal("wrong number of txs", len(txs))
+	}
+	if txs[0].TxResponse.Code != 1 || txs[0].TxResponse.Height != 100 || txs[0].Tx.Body.Memo != "memo" {
+		t.Error("wrong tx", txs[0].TxResponse, txs[0].Tx.Body.Memo)
+	}
+}
@@ -0,0 +1,108 @@
+package hub3
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kwanifi/numiscan-api/config"
+	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/kwanifi/numiscan-api/services/helpers"
+)
+
+const (
+	slashEvent    = "slash"
+	livenessEvent = "liveness"
+)
+
+// slashingQuerier gives the slash fractions used to estimate the burned amount.
+type slashingQuerier interface {
+	GetSlashingParams() (params SlashingParams, err error)
+}
+
+func (e BlockEvent) attribute(key string) string {
+	for _, attr := range e.Attributes {
+		if string(attr.Key) == key {
+			return string(attr.Value)
+		}
+	}
+	return ""
+}
+
+// parseSlashes reads the slashes of the block from the begin and end block events. A double sign slash
+// and the jail of the validator are separate events, a liveness slash has the jail in the same event.
+// The liveness events repeat the missed blocks, only the counter at the slash is kept.
+// The infraction height of double signs is taken from the evidence of the block.
+func (d *data) parseSlashes(block Block, results BlockResults, slashing slashingQuerier, chain config.Chain) error {
+	var params *SlashingParams
+	missedBlocks := make(map[string]uint64)
+	index := make(map[string]int)
+	events := append(results.BeginBlockEvents, results.EndBlockEvents...)
+	for _, event := range events {
+		switch event.Type {
+		case livenessEvent:
+			address, err := helpers.GetHexAddressFromBech32(event.attribute("address"))
+			if err != nil {
+				return fmt.Errorf("liveness: %s", err.Error())
+			}
+			missedBlocks[address], _ = strconv.ParseUint(event.attribute("missed_blocks"), 10, 64)
+		case slashEvent:
+			if event.attribute("address") == "" {
+				address, err := helpers.GetHexAddressFromBech32(event.attribute("jailed"))
+				if err != nil {
+					return fmt.Errorf("slash: %s", err.Error())
+				}
+				if i, ok := index[address]; ok {
+					d.slashes[i].Jailed = true
+				}
+				continue
+			}
+			address, err := helpers.GetHexAddressFromBech32(event.attribute("address"))
+			if err != nil {
+				return fmt.Errorf("slash: %s", err.Error())
+			}
+			power, err := strconv.ParseUint(event.attribute("power"), 10, 64)
+			if err != nil {
+				return fmt.Errorf("slash: power: %s", err.Error())
+			}
+			if params == nil {
+				p, err := slashing.GetSlashingParams()
+				if err != nil {
+					return fmt.Errorf("GetSlashingParams: %s", err.Error())
+				}
+				params = &p
+			}
+			reason := event.attribute("reason")
+			fraction := params.Params.SlashFractionDowntime
+			infractionHeight := block.Block.Header.Height
+			if reason == dmodels.SlashReasonDoubleSign {
+				fraction = params.Params.SlashFractionDoubleSign
+				for _, evidence := range block.Block.Evidence.Evidence {
+					if evidence.Value.VoteA.ValidatorAddress == address {
+						infractionHeight = evidence.Value.VoteA.Height
+					}
+				}
+			}
+			burned, err := d.denoms.convert(Amount{
+				Denom:  d.denoms.base,
+				Amount: chain.Power(int64(power)).Mul(fraction),
+			})
+			if err != nil {
+				return fmt.Errorf("convert: %s", err.Error())
+			}
+			index[address] = len(d.slashes)
+			d.slashes = append(d.slashes, dmodels.Slash{
+				ID:               makeHash(fmt.Sprintf("%d.%s.%s", block.Block.Header.Height, address, reason)),
+				Height:           block.Block.Header.Height,
+				InfractionHeight: infractionHeight,
+				Validator:        address,
+				Reason:           reason,
+				Power:            power,
+				Burned:           burned.Amount,
+				MissedBlocks:     missedBlocks[address],
+				Jailed:           event.attribute("jailed") != "",
+				CreatedAt:        dmodels.NewTime(block.Block.Header.Time),
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package hub3
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/kwanifi/numiscan-api/config"
+	"github.com/shopspring/decimal"
+)
+
+type testSlashing struct{}
+
+func (testSlashing) GetSlashingParams() (params SlashingParams, err error) {
+	params.Params.SlashFractionDoubleSign = decimal.NewFromFloat(0.05)
+	params.Params.SlashFractionDowntime = decimal.NewFromFloat(0.0001)
+	return params, nil
+}
+
+func TestParseSlashes(t *testing.T) {
+	consAddress, _ := bech32.ConvertAndEncode("cosmosvalcons", []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20})
+	raw := fmt.Sprintf(`{"begin_block_events":[
+		{"type":"slash","attributes":[%s,%s,%s]},
+		{"type":"slash","attributes":[%s]}
+	]}`, eventAttribute("address", consAddress), eventAttribute("power", "1000"), eventAttribute("reason", "double_sign"), eventAttribute("jailed", consAddress))
+	var results BlockResults
+	err := json.Unmarshal([]byte(raw), &results)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var block Block
+	block.Block.Header.Height = 100
+	var evidence BlockEvidence
+	evidence.Value.VoteA.Height = 98
+	evidence.Value.VoteA.ValidatorAddress = "0102030405060708090A0B0C0D0E0F1011121314"
+	block.Block.Evidence.Evidence = []BlockEvidence{evidence}
+
+	d := data{denoms: newDenoms(nil, config.DefaultChain, nil)}
+	err = d.parseSlashes(block, results, testSlashing{}, config.DefaultChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.slashes) != 1 {
+		t.Fatal("wrong number of slashes", d.slashes)
+	}
+	slash := d.slashes[0]
+	if slash.Validator != evidence.Value.VoteA.ValidatorAddress || slash.InfractionHeight != 98 || !slash.Jailed {
+		t.Error("wrong slash", slash)
+	}
+	if !slash.Burned.Equal(decimal.NewFromInt(50)) {
+		t.Error("wrong burned amount", slash.Burned)
+	}
+}
@@ -0,0 +1,59 @@
+package hub3
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kwanifi/numiscan-api/config"
+	"github.com/shopspring/decimal"
+)
+
+func TestParseBeginRedelegateMsg(t *testing.T) {
+	var tx Tx
+	tx.TxResponse.Height = 100
+	tx.TxResponse.Hash = "ABCDEF"
+	tx.TxResponse.Timestamp = time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	err := json.Unmarshal([]byte(`[
+		{"msg_index":0,"events":[{"type":"message","attributes":[{"key":"action","value":"send"}]}]},
+		{"msg_index":1,"events":[{"type":"redelegate","attributes":[
+			{"key":"source_validator","value":"cosmosvaloper1src"},
+			{"key":"destination_validator","value":"cosmosvaloper1dst"},
+			{"key":"amount","value":"2500000"},
+			{"key":"completion_time","value":"2021-03-22T00:00:00Z"}
+		]}]}
+	]`), &tx.TxResponse.Logs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte(`{
+		"delegator_address":"cosmos1delegator",
+		"validator_src_address":"cosmosvaloper1src",
+		"validator_dst_address":"cosmosvaloper1dst",
+		"amount":{"denom":"uatom","amount":"2500000"}
+	}`)
+
+	d := data{denoms: newDenoms(nil, config.DefaultChain, nil)}
+	err = d.parseBeginRedelegateMsg(1, tx, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.delegations) != 2 || len(d.redelegations) != 1 {
+		t.Fatal("wrong number of delegations", d.delegations, d.redelegations)
+	}
+	redelegation := d.redelegations[0]
+	if redelegation.ValidatorSrc != "cosmosvaloper1src" || redelegation.ValidatorDst != "cosmosvaloper1dst" || redelegation.Height != 100 {
+		t.Error("wrong redelegation", redelegation)
+	}
+	if !redelegation.Amount.Equal(decimal.NewFromFloat(2.5)) {
+		t.Error("wrong amount", redelegation.Amount)
+	}
+	if !redelegation.CompletionTime.Equal(time.Date(2021, 3, 22, 0, 0, 0, 0, time.UTC)) {
+		t.Error("wrong completion time", redelegation.CompletionTime)
+	}
+
+	err = d.parseBeginRedelegateMsg(0, tx, msg)
+	if err == nil {
+		t.Error("expected an error without the redelegate event")
+	}
+}
@@ -0,0 +1,126 @@
+package hub3
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/kwanifi/numiscan-api/log"
+)
+
+const (
+	// streamPollInterval is how often the latest block is polled while the subscription is on.
+	streamPollInterval = 10 * time.Second
+	// streamReadTimeout drops the connection when no block came for that long.
+	streamReadTimeout = time.Minute
+	streamMinBackoff  = time.Second
+	streamMaxBackoff  = time.Minute
+)
+
+type streamEvent struct {
+	Result struct {
+		Data struct {
+			Value struct {
+				Block struct {
+					Header struct {
+						Height uint64 `json:"height,string"`
+					} `json:"header"`
+				} `json:"block"`
+			} `json:"value"`
+		} `json:"data"`
+	} `json:"result"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    string `json:"data"`
+	} `json:"error"`
+}
+
+// subscribe pushes the heights of new blocks from the tendermint websocket
+// into heads, reconnecting with an exponential backoff until the parser is stopped.
+func (p *Parser) subscribe(heads chan<- uint64) {
+	backoff := streamMinBackoff
+	for {
+		started := time.Now()
+		err := p.stream(heads)
+		select {
+		case <-p.ctx.Done():
+			return
+		default:
+		}
+		if time.Since(started) > streamMaxBackoff {
+			backoff = streamMinBackoff
+		}
+		log.Warn("Parser: stream: %s, reconnecting in %s", err.Error(), backoff)
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > streamMaxBackoff {
+			backoff = streamMaxBackoff
+		}
+	}
+}
+
+// stream reads NewBlock events of a single connection until it fails.
+func (p *Parser) stream(heads chan<- uint64) error {
+	address := strings.TrimSuffix(p.cfg.Parser.RPC, "/") + "/websocket"
+	address = strings.Replace(address, "http", "ws", 1)
+	conn, _, err := websocket.DefaultDialer.Dial(address, nil)
+	if err != nil {
+		return fmt.Errorf("websocket.Dial: %s", err.Error())
+	}
+	defer conn.Close()
+	// unblocks the read on stop, the goroutine ends with the connection
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-p.ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	err = conn.WriteJSON(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "subscribe",
+		"id":      0,
+		"params":  map[string]string{"query": "tm.event='NewBlock'"},
+	})
+	if err != nil {
+		return fmt.Errorf("conn.WriteJSON: %s", err.Error())
+	}
+	for {
+		err = conn.SetReadDeadline(time.Now().Add(streamReadTimeout))
+		if err != nil {
+			return fmt.Errorf("conn.SetReadDeadline: %s", err.Error())
+		}
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return fmt.Errorf("conn.ReadMessage: %s", err.Error())
+		}
+		var event streamEvent
+		err = json.Unmarshal(data, &event)
+		if err != nil {
+			return fmt.Errorf("json.Unmarshal: %s", err.Error())
+		}
+		if event.Error != nil {
+			return fmt.Errorf("rpc error: %d, %s %s", event.Error.Code, event.Error.Message, event.Error.Data)
+		}
+		height := event.Result.Data.Value.Block.Header.Height
+		// the first response only confirms the subscription
+		if height == 0 {
+			continue
+		}
+		select {
+		case <-p.ctx.Done():
+			return nil
+		case heads <- height:
+		}
+	}
+}
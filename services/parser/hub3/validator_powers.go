@@ -0,0 +1,63 @@
+package hub3
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/dmodels"
+)
+
+// validatorSet is the voting power of every validator of the set at the height.
+type validatorSet struct {
+	height    uint64
+	createdAt time.Time
+	powers    map[string]uint64
+}
+
+// saveValidatorPowers writes the powers which differ from the previous height, the sets are sorted by height.
+// The powers before the first set are taken from the database, so a reindexed range gives the same rows.
+func (p *Parser) saveValidatorPowers(sets []validatorSet) error {
+	if len(sets) == 0 {
+		return nil
+	}
+	prev := make(map[string]uint64)
+	if sets[0].height > 1 {
+		items, err := p.dao.GetValidatorPowers(filters.ValidatorPowers{Height: sets[0].height - 1})
+		if err != nil {
+			return fmt.Errorf("dao.GetValidatorPowers: %s", err.Error())
+		}
+		for _, item := range items {
+			prev[item.Validator] = item.Value
+		}
+	}
+	return p.dao.CreateValidatorPowers(powerChanges(prev, sets))
+}
+
+// powerChanges returns the rows for the validators whose power differs from the previous set,
+// a validator which left the set gets zero power.
+func powerChanges(prev map[string]uint64, sets []validatorSet) (changes []dmodels.ValidatorPower) {
+	add := func(set validatorSet, validator string, power uint64) {
+		changes = append(changes, dmodels.ValidatorPower{
+			ID:        makeHash(fmt.Sprintf("%d.%s", set.height, validator)),
+			Height:    set.height,
+			Validator: validator,
+			Power:     power,
+			CreatedAt: set.createdAt,
+		})
+	}
+	for _, set := range sets {
+		for validator, power := range set.powers {
+			if prevPower, ok := prev[validator]; !ok || prevPower != power {
+				add(set, validator, power)
+			}
+		}
+		for validator := range prev {
+			if _, ok := set.powers[validator]; !ok {
+				add(set, validator, 0)
+			}
+		}
+		prev = set.powers
+	}
+	return changes
+}
@@ -0,0 +1,32 @@
+package hub3
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPowerChanges(t *testing.T) {
+	prev := map[string]uint64{"A": 10, "B": 20}
+	sets := []validatorSet{
+		{height: 5, createdAt: time.Now(), powers: map[string]uint64{"A": 10, "B": 25}},
+		{height: 6, createdAt: time.Now(), powers: map[string]uint64{"A": 10, "B": 25, "C": 5}},
+		{height: 7, createdAt: time.Now(), powers: map[string]uint64{"B": 25, "C": 5}},
+	}
+	changes := powerChanges(prev, sets)
+	if len(changes) != 3 {
+		t.Error("wrong number of changes", changes)
+		return
+	}
+	if changes[0].Height != 5 || changes[0].Validator != "B" || changes[0].Power != 25 {
+		t.Error("wrong power change", changes[0])
+	}
+	if changes[1].Height != 6 || changes[1].Validator != "C" || changes[1].Power != 5 {
+		t.Error("wrong new validator", changes[1])
+	}
+	if changes[2].Height != 7 || changes[2].Validator != "A" || changes[2].Power != 0 {
+		t.Error("validator out of the set should get zero power", changes[2])
+	}
+	if len(powerChanges(sets[2].powers, sets[2:])) != 0 {
+		t.Error("same set should give no changes")
+	}
+}
@@ -0,0 +1,76 @@
+package hub3
+
+import (
+	"fmt"
+
+	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	commissionEvent     = "commission"
+	rewardsEvent        = "rewards"
+	proposerRewardEvent = "proposer_reward"
+)
+
+// parseValidatorRevenues sums the distribution events of the begin block by validators.
+// Only the staking denom is kept, the amounts are decimal coins of the fee pool.
+func (d *data) parseValidatorRevenues(block Block, results BlockResults) error {
+	revenues := make(map[string]*dmodels.ValidatorRevenue)
+	var order []string
+	for _, event := range results.BeginBlockEvents {
+		if event.Type != commissionEvent && event.Type != rewardsEvent && event.Type != proposerRewardEvent {
+			continue
+		}
+		validator := event.attribute("validator")
+		if validator == "" {
+			return fmt.Errorf("%s: empty validator", event.Type)
+		}
+		amount, err := d.stakingAmount(event.attribute("amount"))
+		if err != nil {
+			return fmt.Errorf("%s: %s", event.Type, err.Error())
+		}
+		revenue, ok := revenues[validator]
+		if !ok {
+			revenue = &dmodels.ValidatorRevenue{
+				ID:        makeHash(fmt.Sprintf("%d.%s", block.Block.Header.Height, validator)),
+				Height:    block.Block.Header.Height,
+				Validator: validator,
+				CreatedAt: block.Block.Header.Time,
+			}
+			revenues[validator] = revenue
+			order = append(order, validator)
+		}
+		switch event.Type {
+		case commissionEvent:
+			revenue.Commission = revenue.Commission.Add(amount)
+		case rewardsEvent:
+			revenue.Rewards = revenue.Rewards.Add(amount)
+		case proposerRewardEvent:
+			revenue.ProposerReward = revenue.ProposerReward.Add(amount)
+		}
+	}
+	for _, validator := range order {
+		d.validatorRevenues = append(d.validatorRevenues, *revenues[validator])
+	}
+	return nil
+}
+
+// stakingAmount returns the amount of the staking denom in the coins string converted to the display denom.
+func (d *data) stakingAmount(str string) (amount decimal.Decimal, err error) {
+	items, err := parseCoins(str)
+	if err != nil {
+		return amount, fmt.Errorf("parseCoins: %s", err.Error())
+	}
+	for _, item := range items {
+		if item.Denom != d.denoms.base {
+			continue
+		}
+		c, err := d.denoms.convert(item)
+		if err != nil {
+			return amount, fmt.Errorf("convert: %s", err.Error())
+		}
+		amount = amount.Add(c.Amount)
+	}
+	return amount, nil
+}
@@ -0,0 +1,54 @@
+package hub3
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/kwanifi/numiscan-api/config"
+	"github.com/shopspring/decimal"
+)
+
+func TestParseValidatorRevenues(t *testing.T) {
+	validator := "cosmosvaloper1test"
+	raw := fmt.Sprintf(`{"begin_block_events":[
+		{"type":"proposer_reward","attributes":[%s,%s]},
+		{"type":"commission","attributes":[%s,%s]},
+		{"type":"rewards","attributes":[%s,%s]},
+		{"type":"transfer","attributes":[%s]}
+	]}`,
+		eventAttribute("amount", "2000000.5uatom"), eventAttribute("validator", validator),
+		eventAttribute("amount", "150000uatom,7.5ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"), eventAttribute("validator", validator),
+		eventAttribute("amount", "1350000uatom"), eventAttribute("validator", validator),
+		eventAttribute("amount", "1uatom"))
+	var results BlockResults
+	err := json.Unmarshal([]byte(raw), &results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var block Block
+	block.Block.Header.Height = 100
+
+	d := data{denoms: newDenoms(nil, config.DefaultChain, nil)}
+	err = d.parseValidatorRevenues(block, results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.validatorRevenues) != 1 {
+		t.Fatal("wrong number of revenues", d.validatorRevenues)
+	}
+	revenue := d.validatorRevenues[0]
+	// the ID is made of the height and the validator, so a reindexed block replaces its rows
+	if revenue.ID != makeHash(fmt.Sprintf("100.%s", validator)) {
+		t.Error("wrong revenue ID", revenue.ID)
+	}
+	if revenue.Validator != validator || revenue.Height != 100 {
+		t.Error("wrong revenue", revenue)
+	}
+	if !revenue.Commission.Equal(decimal.NewFromFloat(0.15)) || !revenue.Rewards.Equal(decimal.NewFromFloat(1.35)) {
+		t.Error("wrong commission or rewards", revenue.Commission, revenue.Rewards)
+	}
+	if !revenue.ProposerReward.Equal(decimal.NewFromFloat(2.0000005)) {
+		t.Error("wrong proposer reward", revenue.ProposerReward)
+	}
+}
@@ -0,0 +1,145 @@
+package hub3
+
+import (
+	"fmt"
+
+	"github.com/kwanifi/numiscan-api/dao/derrors"
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/shopspring/decimal"
+)
+
+const doNotModify = "[do-not-modify]"
+
+// validatorEvent is a parsed create or edit validator message. The state of an edited
+// validator depends on the previous events, so it is completed only when saving.
+type validatorEvent struct {
+	event dmodels.ValidatorEvent
+	edit  *MsgEditValidator
+}
+
+// apply returns the state of the validator after the edit message.
+func (m MsgEditValidator) apply(prev dmodels.ValidatorEvent, event dmodels.ValidatorEvent) (dmodels.ValidatorEvent, error) {
+	state := prev
+	state.ID = event.ID
+	state.TxHash = event.TxHash
+	state.Height = event.Height
+	state.Type = event.Type
+	state.OperatorAddress = event.OperatorAddress
+	state.SelfBond = decimal.Zero
+	state.CreatedAt = event.CreatedAt
+	if m.Description.Moniker != doNotModify {
+		state.Moniker = m.Description.Moniker
+	}
+	if m.Description.Identity != doNotModify {
+		state.Identity = m.Description.Identity
+	}
+	if m.Description.Website != doNotModify {
+		state.Website = m.Description.Website
+	}
+	if m.Description.Details != doNotModify {
+		state.Details = m.Description.Details
+	}
+	var err error
+	if m.CommissionRate != "" {
+		state.CommissionRate, err = decimal.NewFromString(m.CommissionRate)
+		if err != nil {
+			return state, fmt.Errorf("commission_rate: %s", err.Error())
+		}
+	}
+	if m.MinSelfDelegation != "" {
+		state.MinSelfDelegation, err = decimal.NewFromString(m.MinSelfDelegation)
+		if err != nil {
+			return state, fmt.Errorf("min_self_delegation: %s", err.Error())
+		}
+	}
+	return state, nil
+}
+
+// saveValidatorEvents completes the edit events with the previous state of the validator,
+// saves the history and brings the validators table up to date.
+func (p *Parser) saveValidatorEvents(items []validatorEvent) error {
+	if len(items) == 0 {
+		return nil
+	}
+	last := make(map[string]dmodels.ValidatorEvent)
+	events := make([]dmodels.ValidatorEvent, len(items))
+	for i, item := range items {
+		event := item.event
+		if item.edit != nil {
+			prev, ok := last[event.OperatorAddress]
+			if !ok {
+				found, err := p.dao.GetValidatorEvents(filters.ValidatorEvents{
+					Validator:    event.OperatorAddress,
+					BeforeHeight: event.Height,
+					Limit:        1,
+				})
+				if err != nil {
+					return fmt.Errorf("dao.GetValidatorEvents: %s", err.Error())
+				}
+				if len(found) != 0 {
+					prev = found[0]
+				}
+			}
+			var err error
+			event, err = item.edit.apply(prev, event)
+			if err != nil {
+				return fmt.Errorf("tx %s: %s", event.TxHash, err.Error())
+			}
+		}
+		last[event.OperatorAddress] = event
+		events[i] = event
+	}
+	err := p.dao.CreateValidatorEvents(events)
+	if err != nil {
+		return fmt.Errorf("dao.CreateValidatorEvents: %s", err.Error())
+	}
+	for operator := range last {
+		err = p.syncValidator(operator)
+		if err != nil {
+			return fmt.Errorf("syncValidator: %s", err.Error())
+		}
+	}
+	return nil
+}
+
+// syncValidator writes the latest known state of the validator into the validators table.
+// The latest event is taken from the history, so reindexing an old range does not roll the table back.
+func (p *Parser) syncValidator(operator string) error {
+	events, err := p.dao.GetValidatorEvents(filters.ValidatorEvents{Validator: operator, Limit: 1})
+	if err != nil {
+		return fmt.Errorf("dao.GetValidatorEvents: %s", err.Error())
+	}
+	if len(events) == 0 || events[0].ConsAddress == "" {
+		return nil
+	}
+	event := events[0]
+	validator, err := p.dao.GetValidator(event.ConsAddress)
+	if err != nil && err.Error() != derrors.ErrNotFound {
+		return fmt.Errorf("dao.GetValidator: %s", err.Error())
+	}
+	exists := err == nil
+	validator.ConsAddress = event.ConsAddress
+	validator.ConsPubKey = event.ConsPubKey
+	validator.Address = event.Address
+	validator.OperatorAddress = event.OperatorAddress
+	validator.Name = event.Moniker
+	validator.Description = event.Details
+	validator.Website = event.Website
+	validator.Commission = event.CommissionRate
+	validator.MaxCommission = event.MaxRate
+	if !exists {
+		validator.SelfDelegations = event.SelfBond
+		validator.CreatedAt = event.CreatedAt.Time
+		err = p.dao.CreateValidators([]dmodels.Validator{validator})
+		if err != nil {
+			return fmt.Errorf("dao.CreateValidators: %s", err.Error())
+		}
+		return nil
+	}
+	err = p.dao.UpdateValidators(validator)
+	if err != nil {
+		return fmt.Errorf("dao.UpdateValidators: %s", err.Error())
+	}
+	return nil
+}
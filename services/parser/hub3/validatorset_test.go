@@ -0,0 +1,56 @@
+package hub3
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// newValidatorsetServer serves a validator set of n validators the way the LCD of v0.42 does:
+// without the total and with an error for a page past the end of the set.
+func newValidatorsetServer(n int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("pagination.offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("pagination.limit"))
+		pages := (n + limit - 1) / limit
+		if offset/limit+1 > pages {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, `{"code":2,"message":"page should be within [1, %d] range, given %d","details":[]}`, pages, offset/limit+1)
+			return
+		}
+		var set Validatorsets
+		for i := offset; i < n && i < offset+limit; i++ {
+			set.Validators = append(set.Validators, ValidatorsetItem{Address: fmt.Sprintf("cosmosvalcons%d", i)})
+		}
+		_ = json.NewEncoder(w).Encode(set)
+	}))
+}
+
+func TestGetValidatorsetPaging(t *testing.T) {
+	for _, n := range []int{1, rpcPerPage - 1, rpcPerPage, rpcPerPage + 1, rpcPerPage * 2} {
+		server := newValidatorsetServer(n)
+		set, err := NewAPI(server.URL).GetValidatorset(100)
+		server.Close()
+		if err != nil {
+			t.Fatalf("%d validators: %s", n, err.Error())
+		}
+		if len(set.Validators) != n {
+			t.Errorf("%d validators: got %d", n, len(set.Validators))
+		}
+	}
+}
+
+func TestLastValidatorsPage(t *testing.T) {
+	if lastValidatorsPage(rpcPerPage, rpcPerPage, 0) {
+		t.Error("a full page without the total should not end the set")
+	}
+	if !lastValidatorsPage(rpcPerPage, rpcPerPage, rpcPerPage) {
+		t.Error("a full page reaching the total should end the set")
+	}
+	if !lastValidatorsPage(3, rpcPerPage+3, 0) {
+		t.Error("a short page should end the set")
+	}
+}
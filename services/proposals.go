@@ -6,10 +6,10 @@ import (
 	"strings"
 	"time"
 
-	"github.com/cosmos/cosmos-sdk/types"
 	"github.com/kwanifi/numiscan-api/dao/filters"
 	"github.com/kwanifi/numiscan-api/dmodels"
 	"github.com/kwanifi/numiscan-api/log"
+	"github.com/kwanifi/numiscan-api/services/helpers"
 	"github.com/kwanifi/numiscan-api/services/node"
 	"github.com/kwanifi/numiscan-api/smodels"
 	"github.com/shopspring/decimal"
@@ -43,9 +43,8 @@ func (s *ServiceFacade) UpdateProposals() {
 	}
 	validatorsMap := make(map[string]node.Validator)
 	for _, validator := range validators {
-		bench, _ := types.ValAddressFromBech32(validator.OperatorAddress)
-		accAddress := types.AccAddress(bench.Bytes())
-		validatorsMap[accAddress.String()] = validator
+		accAddress, _ := helpers.ConvertBech32(validator.OperatorAddress, s.cfg.Chain.ValidatorPrefix, s.cfg.Chain.AccountPrefix)
+		validatorsMap[accAddress] = validator
 	}
 
 	totalStake, err := s.node.GetStakingPool()
@@ -106,15 +105,15 @@ func (s *ServiceFacade) UpdateProposals() {
 				log.Error("UpdateProposals: node.ProposalTallyResult: %s", err.Error())
 				return
 			}
-			yes = decimal.NewFromInt(tally.Tally.Yes).Div(node.PrecisionDiv)
-			abstain = decimal.NewFromInt(tally.Tally.Abstain).Div(node.PrecisionDiv)
-			no = decimal.NewFromInt(tally.Tally.No).Div(node.PrecisionDiv)
-			noWithVeto = decimal.NewFromInt(tally.Tally.NoWithVeto).Div(node.PrecisionDiv)
+			yes = decimal.NewFromInt(tally.Tally.Yes).Div(s.cfg.Chain.PrecisionDiv())
+			abstain = decimal.NewFromInt(tally.Tally.Abstain).Div(s.cfg.Chain.PrecisionDiv())
+			no = decimal.NewFromInt(tally.Tally.No).Div(s.cfg.Chain.PrecisionDiv())
+			noWithVeto = decimal.NewFromInt(tally.Tally.NoWithVeto).Div(s.cfg.Chain.PrecisionDiv())
 		} else {
-			yes = decimal.NewFromInt(p.FinalTallyResult.Yes).Div(node.PrecisionDiv)
-			abstain = decimal.NewFromInt(p.FinalTallyResult.Abstain).Div(node.PrecisionDiv)
-			no = decimal.NewFromInt(p.FinalTallyResult.No).Div(node.PrecisionDiv)
-			noWithVeto = decimal.NewFromInt(p.FinalTallyResult.NoWithVeto).Div(node.PrecisionDiv)
+			yes = decimal.NewFromInt(p.FinalTallyResult.Yes).Div(s.cfg.Chain.PrecisionDiv())
+			abstain = decimal.NewFromInt(p.FinalTallyResult.Abstain).Div(s.cfg.Chain.PrecisionDiv())
+			no = decimal.NewFromInt(p.FinalTallyResult.No).Div(s.cfg.Chain.PrecisionDiv())
+			noWithVeto = decimal.NewFromInt(p.FinalTallyResult.NoWithVeto).Div(s.cfg.Chain.PrecisionDiv())
 		}
 
 		turnout := decimal.Zero
@@ -133,15 +132,15 @@ func (s *ServiceFacade) UpdateProposals() {
 		var status string
 		switch p.Status {
 		case node.DepositPeriodProposalStatus:
-			status = "DepositPeriod"
+			status = dmodels.ProposalStatusDepositPeriod
 		case node.VotingPeriodProposalStatus:
-			status = "VotingPeriod"
+			status = dmodels.ProposalStatusVotingPeriod
 		case node.PassedProposalStatus:
-			status = "Passed"
+			status = dmodels.ProposalStatusPassed
 		case node.RejectedProposalStatus:
-			status = "Rejected"
+			status = dmodels.ProposalStatusRejected
 		case node.FailedProposalStatus:
-			status = "Failed"
+			status = dmodels.ProposalStatusFailed
 		}
 
 		proposalType := p.Content.Type
@@ -165,7 +164,7 @@ func (s *ServiceFacade) UpdateProposals() {
 			VotesNoWithVeto:   noWithVeto,
 			SubmitTime:        dmodels.NewTime(p.SubmitTime),
 			DepositEndTime:    dmodels.NewTime(p.DepositEndTime),
-			TotalDeposits:     totalDeposit.Div(node.PrecisionDiv),
+			TotalDeposits:     totalDeposit.Div(s.cfg.Chain.PrecisionDiv()),
 			VotingStartTime:   dmodels.NewTime(p.VotingStartTime),
 			VotingEndTime:     dmodels.NewTime(p.VotingEndTime),
 			Voters:            uint64(votersTotal),
@@ -204,9 +203,8 @@ func (s *ServiceFacade) GetProposalVotes(filter filters.ProposalVotes) (items []
 	}
 	validatorsMap := make(map[string]node.Validator)
 	for _, validator := range vm {
-		bench, _ := types.ValAddressFromBech32(validator.OperatorAddress)
-		accAddress := types.AccAddress(bench.Bytes())
-		validatorsMap[accAddress.String()] = validator
+		accAddress, _ := helpers.ConvertBech32(validator.OperatorAddress, s.cfg.Chain.ValidatorPrefix, s.cfg.Chain.AccountPrefix)
+		validatorsMap[accAddress] = validator
 	}
 	votesMap := make(map[string]dmodels.ProposalVote)
 	for _, vote := range votes {
@@ -234,6 +232,9 @@ func (s *ServiceFacade) GetProposalDeposits(filter filters.ProposalDeposits) (de
 	if err != nil {
 		return nil, fmt.Errorf("dao.GetProposalDeposits: %s", err.Error())
 	}
+	for i, deposit := range deposits {
+		deposits[i].Currency = s.displayCurrency(deposit.Currency)
+	}
 	return deposits, nil
 }
 
@@ -248,9 +249,8 @@ func (s *ServiceFacade) GetProposalsChartData() (items []smodels.ProposalChartDa
 	}
 	validatorsMap := make(map[string]node.Validator)
 	for _, validator := range validators {
-		bench, _ := types.ValAddressFromBech32(validator.OperatorAddress)
-		accAddress := types.AccAddress(bench.Bytes())
-		validatorsMap[accAddress.String()] = validator
+		accAddress, _ := helpers.ConvertBech32(validator.OperatorAddress, s.cfg.Chain.ValidatorPrefix, s.cfg.Chain.AccountPrefix)
+		validatorsMap[accAddress] = validator
 	}
 
 	for _, p := range proposals {
@@ -15,6 +15,7 @@ const (
 	intervalRunType   runType = "interval"
 	periodRunType     runType = "period"
 	everyDayRunType   runType = "every_day"
+	everyWeekRunType  runType = "every_week"
 	everyMonthRunType runType = "every_month"
 )
 
@@ -29,6 +30,7 @@ type (
 	}
 	atTime struct {
 		day     int
+		weekday time.Weekday
 		hours   int
 		minutes int
 	}
@@ -85,6 +87,19 @@ func (sch *Scheduler) EveryDayAt(process Process, hours int, minutes int) {
 	sch.addTask(tsk)
 }
 
+func (sch *Scheduler) EveryWeekAt(process Process, weekday time.Weekday, hours int, minutes int) {
+	tsk := task{
+		runType: everyWeekRunType,
+		process: process,
+		atTime: atTime{
+			weekday: weekday,
+			hours:   hours,
+			minutes: minutes,
+		},
+	}
+	sch.addTask(tsk)
+}
+
 func (sch *Scheduler) EveryMonthAt(process Process, day int, hours int, minutes int) {
 	tsk := task{
 		runType: everyMonthRunType,
@@ -140,6 +155,11 @@ func (sch *Scheduler) runTask(t task) {
 			runEveryDayAt(sch.ctx, t.process, t.atTime)
 			sch.wg.Done()
 		}()
+	case everyWeekRunType:
+		go func() {
+			runEveryWeekAt(sch.ctx, t.process, t.atTime)
+			sch.wg.Done()
+		}()
 	case everyMonthRunType:
 		go func() {
 			runEveryMonthAt(sch.ctx, t.process, t.atTime)
@@ -228,6 +248,30 @@ func runEveryDayAt(ctx context.Context, process Process, a atTime) {
 	}
 }
 
+func runEveryWeekAt(ctx context.Context, process Process, a atTime) {
+	for {
+		now := time.Now()
+		year, month, day := now.Date()
+		today := time.Date(year, month, day, a.hours, a.minutes, 0, 0, time.Local)
+		days := (int(a.weekday) - int(now.Weekday()) + 7) % 7
+		timeInCurrentWeek := today.AddDate(0, 0, days)
+		var duration time.Duration
+		if timeInCurrentWeek.After(now) {
+			duration = timeInCurrentWeek.Sub(now)
+		} else {
+			nextWeek := timeInCurrentWeek.AddDate(0, 0, 7)
+			duration = nextWeek.Sub(now)
+		}
+		next := time.After(duration)
+		select {
+		case <-ctx.Done():
+			return
+		case <-next:
+			process()
+		}
+	}
+}
+
 func runEveryMonthAt(ctx context.Context, process Process, a atTime) {
 	for {
 		now := time.Now()
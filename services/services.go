@@ -1,6 +1,8 @@
 package services
 
 import (
+	"fmt"
+
 	"github.com/kwanifi/numiscan-api/config"
 	"github.com/kwanifi/numiscan-api/dao"
 	"github.com/kwanifi/numiscan-api/dao/filters"
@@ -24,11 +26,19 @@ type (
 		GetAggBlocksCount(filter filters.Agg) (items []smodels.AggItem, err error)
 		GetAggBlocksDelay(filter filters.Agg) (items []smodels.AggItem, err error)
 		GetAggUniqBlockValidators(filter filters.Agg) (items []smodels.AggItem, err error)
+		GetBlocks(filter filters.Blocks) (resp smodels.PaginatableResponse, err error)
+		GetBlock(height uint64) (block smodels.BlockDetails, err error)
+		GetTransactions(filter filters.Transactions) (resp smodels.PaginatableResponse, err error)
+		GetTransaction(hash string) (tx smodels.TransactionDetails, err error)
 		GetAggDelegationsVolume(filter filters.DelegationsAgg) (items []smodels.AggItem, err error)
 		GetAggUndelegationsVolume(filter filters.Agg) (items []smodels.AggItem, err error)
 		GetNetworkStates(filter filters.Stats) (map[string][]decimal.Decimal, error)
 		GetStakingPie() (pie smodels.Pie, err error)
 		MakeUpdateBalances()
+		RefreshDirtyAccounts()
+		GetAccount(address string) (account smodels.Account, err error)
+		GetAccountTransactions(filter filters.AccountTransactions) (resp smodels.PaginatableResponse, err error)
+		GetAccountBalanceAgg(filter filters.BalanceUpdatesAgg) (items []smodels.BalanceAggItem, err error)
 		GetSizeOfNode() (size float64, err error)
 		MakeStats()
 		UpdateProposals()
@@ -40,7 +50,14 @@ type (
 		GetValidators() (validators []smodels.Validator, err error)
 		UpdateValidators()
 		GetAvgOperationsPerBlock(filter filters.Agg) (items []smodels.AggItem, err error)
+		GetAggFailedTransactionsRate(filter filters.Agg) (items []smodels.AggItem, err error)
+		GetAggMessageTypes(filter filters.MessageTypesAgg) (items []smodels.MessageTypeAggItem, err error)
 		GetAggWhaleAccounts(filter filters.Agg) (items []smodels.AggItem, err error)
+		GetTopAccounts(filter filters.TopAccounts) (resp smodels.PaginatableResponse, err error)
+		GetAccountsDistribution() (distribution smodels.AccountsDistribution, err error)
+		GetDecentralization() (report smodels.Decentralization, err error)
+		GetAggDecentralization(filter filters.Agg) (agg smodels.DecentralizationAgg, err error)
+		GetAggAccountsDistribution(filter filters.Agg) (items []smodels.DistributionRangeAgg, err error)
 		GetTopProposedBlocksValidators() (items []dmodels.ValidatorValue, err error)
 		GetMostJailedValidators() (items []dmodels.ValidatorValue, err error)
 		GetFeeRanges() (items []smodels.FeeRange, err error)
@@ -50,9 +67,24 @@ type (
 		GetValidatorDelegationsAgg(validatorAddress string) (items []smodels.AggItem, err error)
 		GetValidatorDelegatorsAgg(validatorAddress string) (items []smodels.AggItem, err error)
 		GetValidatorBlocksStat(validatorAddress string) (stat smodels.ValidatorBlocksStat, err error)
+		GetValidatorVotes(validatorAddress string, filter filters.Participation) (votes smodels.ValidatorVotes, err error)
+		GetGovernanceValidators(filter filters.Participation) (items []smodels.ValidatorParticipation, err error)
+		GetValidatorUptime(validatorAddress string, filter filters.Uptime) (uptime smodels.ValidatorUptime, err error)
+		GetValidatorsUptime() (uptime smodels.ValidatorsUptime, err error)
+		GetValidatorPowers(filter filters.ValidatorPowers) (items []smodels.ValidatorPower, err error)
+		GetValidatorRevenueAgg(filter filters.ValidatorRevenueAgg) (items []smodels.ValidatorRevenueAggItem, err error)
+		GetStakingAPR() (apr smodels.StakingAPR, err error)
+		GetStakingCalculation(filter filters.StakingCalculator) (calc smodels.StakingCalculation, err error)
 		GetValidatorDelegators(filter filters.ValidatorDelegators) (resp smodels.PaginatableResponse, err error)
+		GetValidatorHistory(filter filters.ValidatorEvents) (resp smodels.PaginatableResponse, err error)
+		GetSlashes(filter filters.Slashes) (resp smodels.PaginatableResponse, err error)
+		GetValidatorSlashes(address string, filter filters.Slashes) (resp smodels.PaginatableResponse, err error)
 		GetAggBondedRatio(filter filters.Agg) (items []smodels.AggItem, err error)
 		GetAggUnbondingVolume(filter filters.Agg) (items []smodels.AggItem, err error)
+		GetUnbondingSchedule(filter filters.UnbondingSchedule) (items []smodels.AggItem, err error)
+		GetRedelegationFlows(filter filters.RedelegationFlows) (items []smodels.RedelegationFlow, err error)
+		GetIBCTransfers(filter filters.IBCTransfers) (resp smodels.PaginatableResponse, err error)
+		GetAggIBCChannelsVolume(filter filters.IBCChannelsAgg) (items []smodels.IBCChannelAggItem, err error)
 		Test() (state dmodels.HistoricalState, err error)
 	}
 	CMC interface {
@@ -70,6 +102,8 @@ type (
 		GetProposals() (proposals node.ProposalsResult, err error)
 		GetDelegatorValidatorStake(delegator string, validator string) (amount decimal.Decimal, err error)
 		ProposalTallyResult(id uint64) (result node.ProposalTallyResult, err error)
+		GetSlashingParams() (params node.SlashingParams, err error)
+		GetDistributionParams() (params node.DistributionParams, err error)
 	}
 
 	ServiceFacade struct {
@@ -81,10 +115,17 @@ type (
 )
 
 func NewServices(d dao.DAO, cfg config.Config) (svc Services, err error) {
+	var nodeAPI Node = node.NewAPI(cfg)
+	if cfg.Parser.NodeClient == config.NodeClientGRPC {
+		nodeAPI, err = node.NewGRPCAPI(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("node.NewGRPCAPI: %s", err.Error())
+		}
+	}
 	return &ServiceFacade{
 		dao:  d,
 		cfg:  cfg,
 		cmc:  cmc.NewCMC(cfg),
-		node: node.NewAPI(cfg),
+		node: nodeAPI,
 	}, nil
 }
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/services/helpers"
+	"github.com/kwanifi/numiscan-api/smodels"
+)
+
+func (s *ServiceFacade) GetSlashes(filter filters.Slashes) (resp smodels.PaginatableResponse, err error) {
+	items, err := s.dao.GetSlashes(filter)
+	if err != nil {
+		return resp, fmt.Errorf("dao.GetSlashes: %s", err.Error())
+	}
+	total, err := s.dao.GetSlashesTotal(filter)
+	if err != nil {
+		return resp, fmt.Errorf("dao.GetSlashesTotal: %s", err.Error())
+	}
+	return smodels.PaginatableResponse{
+		Items: items,
+		Total: total,
+	}, nil
+}
+
+func (s *ServiceFacade) GetValidatorSlashes(address string, filter filters.Slashes) (resp smodels.PaginatableResponse, err error) {
+	consAddress, err := s.getConsAddress(address)
+	if err != nil {
+		return resp, fmt.Errorf("getConsAddress: %s", err.Error())
+	}
+	if consAddress == "" {
+		return smodels.PaginatableResponse{Items: []interface{}{}}, nil
+	}
+	filter.Validators = []string{consAddress}
+	return s.GetSlashes(filter)
+}
+
+// getConsAddress returns the hex consensus address of the operator address, the validators
+// that left the set are looked up in the validator history. It is empty for unknown validators.
+func (s *ServiceFacade) getConsAddress(operator string) (string, error) {
+	validators, err := s.GetValidatorMap()
+	if err != nil {
+		return "", fmt.Errorf("GetValidatorMap: %s", err.Error())
+	}
+	if validator, ok := validators[operator]; ok {
+		address, err := helpers.GetHexAddressFromBase64PK(validator.ConsensusPubkey.Key)
+		if err != nil {
+			return "", fmt.Errorf("helpers.GetHexAddressFromBase64PK: %s", err.Error())
+		}
+		return address, nil
+	}
+	events, err := s.dao.GetValidatorEvents(filters.ValidatorEvents{Validator: operator, Limit: 1})
+	if err != nil {
+		return "", fmt.Errorf("dao.GetValidatorEvents: %s", err.Error())
+	}
+	if len(events) == 0 {
+		return "", nil
+	}
+	return events[0].ConsAddress, nil
+}
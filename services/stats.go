@@ -11,7 +11,6 @@ import (
 	"github.com/kwanifi/numiscan-api/dao/filters"
 	"github.com/kwanifi/numiscan-api/dmodels"
 	"github.com/kwanifi/numiscan-api/log"
-	"github.com/kwanifi/numiscan-api/services/node"
 	"github.com/kwanifi/numiscan-api/smodels"
 	"github.com/shopspring/decimal"
 )
@@ -32,6 +31,8 @@ func (s *ServiceFacade) GetNetworkStates(filter filters.Stats) (map[string][]dec
 		dmodels.StatsTotalWhaleAccounts,
 		dmodels.StatsTotalSmallAccounts,
 		dmodels.StatsTotalJailers,
+		dmodels.StatsStakingAPR,
+		dmodels.StatsStakingRealizedAPR,
 	}
 	return s.getStates(filter)
 }
@@ -109,7 +110,7 @@ func (s *ServiceFacade) MakeStats() {
 		{
 			title: dmodels.StatsTransfersVolume,
 			fetch: func() (value decimal.Decimal, err error) {
-				volume, err := s.dao.GetTransferVolume(filters.TimeRange{})
+				volume, err := s.dao.GetTransferVolume(filters.TimeRange{}, s.cfg.Chain.DisplayDenom)
 				if err != nil {
 					return value, fmt.Errorf("dao.GetTransferVolume: %s", err.Error())
 				}
@@ -236,7 +237,7 @@ func (s *ServiceFacade) MakeStats() {
 				}
 				var amounts []decimal.Decimal
 				for _, validator := range mp {
-					amounts = append(amounts, validator.DelegatorShares.Div(node.PrecisionDiv))
+					amounts = append(amounts, validator.DelegatorShares.Div(s.cfg.Chain.PrecisionDiv()))
 				}
 				sort.Slice(amounts, func(i, j int) bool {
 					return amounts[i].GreaterThan(amounts[j])
@@ -258,6 +259,26 @@ func (s *ServiceFacade) MakeStats() {
 				return value, nil
 			},
 		},
+		{
+			title: dmodels.StatsStakingAPR,
+			fetch: func() (value decimal.Decimal, err error) {
+				apr, err := s.makeStakingAPR()
+				if err != nil {
+					return value, fmt.Errorf("makeStakingAPR: %s", err.Error())
+				}
+				return apr.APR, nil
+			},
+		},
+		{
+			title: dmodels.StatsStakingRealizedAPR,
+			fetch: func() (value decimal.Decimal, err error) {
+				value, err = s.getRealizedStakingAPR()
+				if err != nil {
+					return value, fmt.Errorf("getRealizedStakingAPR: %s", err.Error())
+				}
+				return value, nil
+			},
+		},
 	}
 
 	var models []dmodels.Stat
@@ -276,7 +297,17 @@ func (s *ServiceFacade) MakeStats() {
 			CreatedAt: startOfToday,
 		})
 	}
-	err := s.dao.CreateStats(models)
+	distributionStats, err := s.makeAccountsDistributionStats(startOfToday)
+	if err != nil {
+		log.Error("MakeStats: makeAccountsDistributionStats: %s", err.Error())
+	}
+	models = append(models, distributionStats...)
+	decentralizationStats, err := s.makeDecentralizationStats(startOfToday)
+	if err != nil {
+		log.Error("MakeStats: makeDecentralizationStats: %s", err.Error())
+	}
+	models = append(models, decentralizationStats...)
+	err = s.dao.CreateStats(models)
 	if err != nil {
 		log.Error("MakeStats: dao.CreateStats: %s", err.Error())
 	}
@@ -3,11 +3,17 @@ package services
 import (
 	"fmt"
 
+	"github.com/kwanifi/numiscan-api/dao/derrors"
 	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/dmodels"
 	"github.com/kwanifi/numiscan-api/smodels"
 )
 
 func (s *ServiceFacade) GetAggTransactionsFee(filter filters.Agg) (items []smodels.AggItem, err error) {
+	// fees in the staking denom are taken from the transactions table
+	if filter.Denom == s.cfg.Chain.DisplayDenom {
+		filter.Denom = ""
+	}
 	items, err = s.dao.GetAggTransactionsFee(filter)
 	if err != nil {
 		return nil, fmt.Errorf("dao.GetAggTransactionsFee: %s", err.Error())
@@ -30,3 +36,139 @@ func (s *ServiceFacade) GetAvgOperationsPerBlock(filter filters.Agg) (items []sm
 	}
 	return items, nil
 }
+
+func (s *ServiceFacade) GetAggFailedTransactionsRate(filter filters.Agg) (items []smodels.AggItem, err error) {
+	items, err = s.dao.GetAggFailedTransactionsRate(filter)
+	if err != nil {
+		return nil, fmt.Errorf("dao.GetAggFailedTransactionsRate: %s", err.Error())
+	}
+	return items, nil
+}
+
+func (s *ServiceFacade) GetAggMessageTypes(filter filters.MessageTypesAgg) (items []smodels.MessageTypeAggItem, err error) {
+	items, err = s.dao.GetAggMessageTypes(filter)
+	if err != nil {
+		return nil, fmt.Errorf("dao.GetAggMessageTypes: %s", err.Error())
+	}
+	return items, nil
+}
+
+func (s *ServiceFacade) GetTransactions(filter filters.Transactions) (resp smodels.PaginatableResponse, err error) {
+	txs, err := s.dao.GetTransactions(filter)
+	if err != nil {
+		return resp, fmt.Errorf("dao.GetTransactions: %s", err.Error())
+	}
+	total, err := s.dao.GetTransactionsTotal(filter)
+	if err != nil {
+		return resp, fmt.Errorf("dao.GetTransactionsTotal: %s", err.Error())
+	}
+	items := make([]smodels.Transaction, len(txs))
+	for i, tx := range txs {
+		items[i] = makeTransaction(tx)
+	}
+	return smodels.PaginatableResponse{
+		Items: items,
+		Total: total,
+	}, nil
+}
+
+// GetTransaction returns the transaction with the operations parsed from its messages.
+func (s *ServiceFacade) GetTransaction(hash string) (tx smodels.TransactionDetails, err error) {
+	txs, err := s.dao.GetTransactions(filters.Transactions{Hash: []string{hash}})
+	if err != nil {
+		return tx, fmt.Errorf("dao.GetTransactions: %s", err.Error())
+	}
+	if len(txs) == 0 {
+		return tx, fmt.Errorf(derrors.ErrNotFound)
+	}
+	tx.Transaction = makeTransaction(txs[0])
+	transfers, err := s.dao.GetTransfers(filters.Transfers{TxHash: hash})
+	if err != nil {
+		return tx, fmt.Errorf("dao.GetTransfers: %s", err.Error())
+	}
+	for _, t := range transfers {
+		tx.Transfers = append(tx.Transfers, smodels.Transfer{
+			TxHash:    t.TxHash,
+			From:      t.From,
+			To:        t.To,
+			Amount:    t.Amount,
+			Currency:  s.displayCurrency(t.Currency),
+			CreatedAt: dmodels.NewTime(t.CreatedAt),
+		})
+	}
+	delegations, err := s.dao.GetDelegations(filters.Delegations{TxHash: hash})
+	if err != nil {
+		return tx, fmt.Errorf("dao.GetDelegations: %s", err.Error())
+	}
+	for _, d := range delegations {
+		tx.Delegations = append(tx.Delegations, smodels.Delegation{
+			TxHash:    d.TxHash,
+			Delegator: d.Delegator,
+			Validator: d.Validator,
+			Amount:    d.Amount,
+			CreatedAt: dmodels.NewTime(d.CreatedAt),
+		})
+	}
+	delegatorRewards, err := s.dao.GetDelegatorRewards(filters.Rewards{TxHash: hash})
+	if err != nil {
+		return tx, fmt.Errorf("dao.GetDelegatorRewards: %s", err.Error())
+	}
+	for _, r := range delegatorRewards {
+		tx.DelegatorRewards = append(tx.DelegatorRewards, smodels.DelegatorReward{
+			TxHash:    r.TxHash,
+			Delegator: r.Delegator,
+			Validator: r.Validator,
+			Amount:    r.Amount,
+			Currency:  s.displayCurrency(r.Currency),
+			CreatedAt: dmodels.NewTime(r.CreatedAt),
+		})
+	}
+	validatorRewards, err := s.dao.GetValidatorRewards(filters.Rewards{TxHash: hash})
+	if err != nil {
+		return tx, fmt.Errorf("dao.GetValidatorRewards: %s", err.Error())
+	}
+	for _, r := range validatorRewards {
+		tx.ValidatorRewards = append(tx.ValidatorRewards, smodels.ValidatorReward{
+			TxHash:    r.TxHash,
+			Address:   r.Address,
+			Amount:    r.Amount,
+			Currency:  s.displayCurrency(r.Currency),
+			CreatedAt: dmodels.NewTime(r.CreatedAt),
+		})
+	}
+	tx.Votes, err = s.dao.GetProposalVotes(filters.ProposalVotes{TxHash: hash})
+	if err != nil {
+		return tx, fmt.Errorf("dao.GetProposalVotes: %s", err.Error())
+	}
+	tx.Deposits, err = s.dao.GetProposalDeposits(filters.ProposalDeposits{TxHash: hash})
+	if err != nil {
+		return tx, fmt.Errorf("dao.GetProposalDeposits: %s", err.Error())
+	}
+	for i, deposit := range tx.Deposits {
+		tx.Deposits[i].Currency = s.displayCurrency(deposit.Currency)
+	}
+	return tx, nil
+}
+
+func makeTransaction(tx dmodels.Transaction) smodels.Transaction {
+	status := dmodels.TransactionStatusSuccess
+	if !tx.Status {
+		status = dmodels.TransactionStatusFailed
+	}
+	return smodels.Transaction{
+		Hash:         tx.Hash,
+		Status:       status,
+		Height:       tx.Height,
+		Messages:     tx.Messages,
+		MessageTypes: tx.MessageTypes,
+		Fee:          tx.Fee,
+		GasUsed:      tx.GasUsed,
+		GasWanted:    tx.GasWanted,
+		Memo:         tx.Memo,
+		FeePayer:     tx.FeePayer,
+		FeeGranter:   tx.FeeGranter,
+		Signers:      tx.Signers,
+		RawLog:       tx.RawLog,
+		CreatedAt:    dmodels.NewTime(tx.CreatedAt),
+	}
+}
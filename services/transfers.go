@@ -8,6 +8,7 @@ import (
 )
 
 func (s *ServiceFacade) GetAggTransfersVolume(filter filters.Agg) (items []smodels.AggItem, err error) {
+	filter.Denom = filter.Currency(s.cfg.Chain.DisplayDenom)
 	items, err = s.dao.GetAggTransfersVolume(filter)
 	if err != nil {
 		return nil, fmt.Errorf("dao.GetAggTransfersVolume: %s", err.Error())
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/services/helpers"
+	"github.com/kwanifi/numiscan-api/services/node"
+	"github.com/kwanifi/numiscan-api/smodels"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	signedBlocksWindowCacheKey = "signed_blocks_window"
+	validatorsUptimeCacheKey   = "validators_uptime"
+)
+
+// GetValidatorUptime returns the uptime of the validator over the heights of the signed blocks window
+// where it was in the active set, its missed blocks streaks and the bitmap of the last `filter.Blocks` heights.
+func (s *ServiceFacade) GetValidatorUptime(validatorAddress string, filter filters.Uptime) (uptime smodels.ValidatorUptime, err error) {
+	validator, err := s.GetValidator(validatorAddress)
+	if err != nil {
+		return uptime, fmt.Errorf("GetValidator: %s", err.Error())
+	}
+	window, err := s.getSignedBlocksWindow()
+	if err != nil {
+		return uptime, fmt.Errorf("getSignedBlocksWindow: %s", err.Error())
+	}
+	latest, err := s.getLatestHeight()
+	if err != nil {
+		return uptime, fmt.Errorf("getLatestHeight: %s", err.Error())
+	}
+	from, window := heightsWindow(latest, window)
+	uptime.Window = window
+	uptime.FromHeight, _ = heightsWindow(latest, filter.Blocks)
+	uptime.ToHeight = latest
+	first := from
+	if uptime.FromHeight < first {
+		first = uptime.FromHeight
+	}
+	active, err := s.getActiveRanges([]string{validator.ConsAddress}, first, latest)
+	if err != nil {
+		return uptime, fmt.Errorf("getActiveRanges: %s", err.Error())
+	}
+	ranges := active[validator.ConsAddress]
+
+	heights, err := s.dao.GetMissedBlocksHeights(filters.MissedBlocks{
+		Validators: []string{validator.ConsAddress},
+		HeightFrom: first,
+		HeightTo:   latest,
+	})
+	if err != nil {
+		return uptime, fmt.Errorf("dao.GetMissedBlocksHeights: %s", err.Error())
+	}
+	for _, height := range heights {
+		if height >= from {
+			uptime.Missed++
+		}
+	}
+	uptime.Active = helpers.RangesLength(cutRanges(ranges, from))
+	uptime.Uptime = uptimePercent(uptime.Missed, uptime.Active)
+	uptime.LongestMissedStreak, uptime.CurrentMissedStreak = helpers.MissedStreaks(heights, latest)
+
+	blocks := []byte(strings.Repeat("-", int(latest-uptime.FromHeight+1)))
+	for _, r := range cutRanges(ranges, uptime.FromHeight) {
+		for height := r.From; height <= r.To; height++ {
+			blocks[height-uptime.FromHeight] = '1'
+		}
+	}
+	for _, height := range heights {
+		if height >= uptime.FromHeight {
+			blocks[height-uptime.FromHeight] = '0'
+		}
+	}
+	uptime.Blocks = string(blocks)
+	return uptime, nil
+}
+
+// GetValidatorsUptime returns the bonded validators ordered by the uptime over the signed blocks window,
+// the uptime of every validator counts only the heights where it was in the active set.
+func (s *ServiceFacade) GetValidatorsUptime() (uptime smodels.ValidatorsUptime, err error) {
+	data, found := s.dao.CacheGet(validatorsUptimeCacheKey)
+	if found {
+		return data.(smodels.ValidatorsUptime), nil
+	}
+	window, err := s.getSignedBlocksWindow()
+	if err != nil {
+		return uptime, fmt.Errorf("getSignedBlocksWindow: %s", err.Error())
+	}
+	latest, err := s.getLatestHeight()
+	if err != nil {
+		return uptime, fmt.Errorf("getLatestHeight: %s", err.Error())
+	}
+	from, window := heightsWindow(latest, window)
+	missedBlocks, err := s.dao.GetValidatorsMissedBlocks(filters.MissedBlocks{
+		HeightFrom: from,
+		HeightTo:   latest,
+	})
+	if err != nil {
+		return uptime, fmt.Errorf("dao.GetValidatorsMissedBlocks: %s", err.Error())
+	}
+	missed := make(map[string]uint64)
+	for _, item := range missedBlocks {
+		missed[item.Validator] = item.Value
+	}
+	validators, err := s.GetValidatorMap()
+	if err != nil {
+		return uptime, fmt.Errorf("GetValidatorMap: %s", err.Error())
+	}
+	uptime.Window = window
+	uptime.ToHeight = latest
+	addresses := make(map[string]string)
+	var consAddresses []string
+	for _, validator := range validators {
+		if validator.Status != node.BondedValidatorStatus {
+			continue
+		}
+		address, err := helpers.GetHexAddressFromBase64PK(validator.ConsensusPubkey.Key)
+		if err != nil {
+			return uptime, fmt.Errorf("helpers.GetHexAddressFromBase64PK: %s", err.Error())
+		}
+		addresses[validator.OperatorAddress] = address
+		consAddresses = append(consAddresses, address)
+	}
+	active, err := s.getActiveRanges(consAddresses, from, latest)
+	if err != nil {
+		return uptime, fmt.Errorf("getActiveRanges: %s", err.Error())
+	}
+	for _, validator := range validators {
+		address, ok := addresses[validator.OperatorAddress]
+		if !ok {
+			continue
+		}
+		activeHeights := helpers.RangesLength(active[address])
+		uptime.Validators = append(uptime.Validators, smodels.ValidatorUptimeItem{
+			OperatorAddress: validator.OperatorAddress,
+			Title:           validator.Description.Moniker,
+			Missed:          missed[address],
+			Active:          activeHeights,
+			Uptime:          uptimePercent(missed[address], activeHeights),
+		})
+	}
+	sort.Slice(uptime.Validators, func(i, j int) bool {
+		if !uptime.Validators[i].Uptime.Equal(uptime.Validators[j].Uptime) {
+			return uptime.Validators[i].Uptime.GreaterThan(uptime.Validators[j].Uptime)
+		}
+		if uptime.Validators[i].Missed != uptime.Validators[j].Missed {
+			return uptime.Validators[i].Missed < uptime.Validators[j].Missed
+		}
+		return uptime.Validators[i].Title < uptime.Validators[j].Title
+	})
+	s.dao.CacheSet(validatorsUptimeCacheKey, uptime, time.Minute)
+	return uptime, nil
+}
+
+// getActiveRanges returns the heights ranges where the validators were in the active set, by the hex consensus addresses.
+// When no powers are stored before `from` (validator_powers is not filled for these heights yet),
+// the validators without power changes in the range are treated as active over the whole range.
+func (s *ServiceFacade) getActiveRanges(validators []string, from uint64, to uint64) (map[string][]helpers.HeightsRange, error) {
+	var initial map[string]uint64
+	if from > 1 {
+		var err error
+		initial, err = s.getValidatorPowers(filters.ValidatorPowers{Height: from - 1})
+		if err != nil {
+			return nil, fmt.Errorf("getValidatorPowers: %s", err.Error())
+		}
+	}
+	items, err := s.dao.GetValidatorPowerChanges(filters.ValidatorPowerChanges{
+		Validators: validators,
+		HeightFrom: from,
+		HeightTo:   to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dao.GetValidatorPowerChanges: %s", err.Error())
+	}
+	changes := make(map[string][]helpers.PowerChange)
+	for _, item := range items {
+		changes[item.Validator] = append(changes[item.Validator], helpers.PowerChange{Height: item.Height, Power: item.Power})
+	}
+	unknown := from > 1 && len(initial) == 0
+	ranges := make(map[string][]helpers.HeightsRange)
+	for _, validator := range validators {
+		if unknown && len(changes[validator]) == 0 {
+			ranges[validator] = []helpers.HeightsRange{{From: from, To: to}}
+			continue
+		}
+		ranges[validator] = helpers.ActiveRanges(initial[validator], changes[validator], from, to)
+	}
+	return ranges, nil
+}
+
+// cutRanges drops the heights below `from` from the ranges.
+func cutRanges(ranges []helpers.HeightsRange, from uint64) (cut []helpers.HeightsRange) {
+	for _, r := range ranges {
+		if r.To < from {
+			continue
+		}
+		if r.From < from {
+			r.From = from
+		}
+		cut = append(cut, r)
+	}
+	return cut
+}
+
+func (s *ServiceFacade) getSignedBlocksWindow() (uint64, error) {
+	data, found := s.dao.CacheGet(signedBlocksWindowCacheKey)
+	if found {
+		return data.(uint64), nil
+	}
+	params, err := s.node.GetSlashingParams()
+	if err != nil {
+		return 0, fmt.Errorf("node.GetSlashingParams: %s", err.Error())
+	}
+	if params.Params.SignedBlocksWindow == 0 {
+		return 0, fmt.Errorf("signed blocks window is zero")
+	}
+	s.dao.CacheSet(signedBlocksWindowCacheKey, params.Params.SignedBlocksWindow, time.Hour)
+	return params.Params.SignedBlocksWindow, nil
+}
+
+func (s *ServiceFacade) getLatestHeight() (uint64, error) {
+	blocks, err := s.dao.GetBlocks(filters.Blocks{Limit: 1})
+	if err != nil {
+		return 0, fmt.Errorf("dao.GetBlocks: %s", err.Error())
+	}
+	if len(blocks) == 0 {
+		return 0, fmt.Errorf("no blocks")
+	}
+	return blocks[0].ID, nil
+}
+
+// heightsWindow returns the first height and the size of the window of n blocks ending at latest,
+// the window is cut by the first height of the chain.
+func heightsWindow(latest uint64, n uint64) (from uint64, size uint64) {
+	if n > latest {
+		n = latest
+	}
+	return latest - n + 1, n
+}
+
+// uptimePercent returns the share of the active heights signed by the validator.
+func uptimePercent(missed uint64, active uint64) decimal.Decimal {
+	if active == 0 || missed >= active {
+		return decimal.Zero
+	}
+	return decimal.NewFromInt(int64(active - missed)).Div(decimal.NewFromInt(int64(active))).Mul(decimal.NewFromInt(100)).Truncate(2)
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kwanifi/numiscan-api/dao/filters"
+	"github.com/kwanifi/numiscan-api/smodels"
+)
+
+// GetValidatorRevenueAgg returns the commission and rewards distributed to the validator by periods
+// together with the commission withdrawn in the staking currency.
+func (s *ServiceFacade) GetValidatorRevenueAgg(filter filters.ValidatorRevenueAgg) (items []smodels.ValidatorRevenueAggItem, err error) {
+	items, err = s.dao.GetAggValidatorRevenues(filter)
+	if err != nil {
+		return nil, fmt.Errorf("dao.GetAggValidatorRevenues: %s", err.Error())
+	}
+	withdrawals, err := s.dao.GetAggValidatorCommissionWithdrawals(filter, s.cfg.Chain.DisplayDenom)
+	if err != nil {
+		return nil, fmt.Errorf("dao.GetAggValidatorCommissionWithdrawals: %s", err.Error())
+	}
+	index := make(map[int64]int)
+	for i, item := range items {
+		index[item.Time.Unix()] = i
+	}
+	for _, withdrawal := range withdrawals {
+		i, ok := index[withdrawal.Time.Unix()]
+		if !ok {
+			items = append(items, smodels.ValidatorRevenueAggItem{Time: withdrawal.Time})
+			i = len(items) - 1
+		}
+		items[i].Withdrawn = withdrawal.Value
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Time.Before(items[j].Time.Time)
+	})
+	return items, nil
+}
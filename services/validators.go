@@ -1,12 +1,10 @@
 package services
 
 import (
-	"encoding/hex"
 	"fmt"
 	"sort"
 	"time"
 
-	"github.com/cosmos/cosmos-sdk/types"
 	"github.com/kwanifi/numiscan-api/dao/filters"
 	"github.com/kwanifi/numiscan-api/dmodels"
 	"github.com/kwanifi/numiscan-api/log"
@@ -78,7 +76,7 @@ func (s *ServiceFacade) GetStakingPie() (pie smodels.Pie, err error) {
 		parts[i] = smodels.PiePart{
 			Label: validators[i].OperatorAddress,
 			Title: validators[i].Description.Moniker,
-			Value: validators[i].DelegatorShares.Div(node.PrecisionDiv),
+			Value: validators[i].DelegatorShares.Div(s.cfg.Chain.PrecisionDiv()),
 		}
 	}
 	pie.Parts = parts
@@ -111,6 +109,19 @@ func (s *ServiceFacade) makeValidators() (validators []smodels.Validator, err er
 	if err != nil {
 		return nil, fmt.Errorf("node.GetStakingPool: %s", err.Error())
 	}
+	// the list is served without the APR rather than failing when the node does not give it
+	stakingAPR, aprErr := s.getNetworkAPR()
+	if aprErr != nil {
+		log.Error("makeValidators: getNetworkAPR: %s", aprErr.Error())
+	}
+	powers, err := s.getValidatorPowers(filters.ValidatorPowers{})
+	if err != nil {
+		return nil, fmt.Errorf("getValidatorPowers: %s", err.Error())
+	}
+	powers24, err := s.getValidatorPowers(filters.ValidatorPowers{At: dmodels.NewTime(time.Now().Add(-time.Hour * 24))})
+	if err != nil {
+		return nil, fmt.Errorf("getValidatorPowers: %s", err.Error())
+	}
 	for _, v := range nodeValidators {
 		consAddress, err := helpers.GetHexAddressFromBase64PK(v.ConsensusPubkey.Key)
 		if err != nil {
@@ -121,15 +132,11 @@ func (s *ServiceFacade) makeValidators() (validators []smodels.Validator, err er
 			return nil, fmt.Errorf("dao.GetProposedBlocksTotal: %s", err.Error())
 		}
 
-		addressBytes, err := types.GetFromBech32(v.OperatorAddress, types.Bech32PrefixValAddr)
-		if err != nil {
-			return nil, fmt.Errorf("types.GetFromBech32: %s", err.Error())
-		}
-		address, err := types.AccAddressFromHex(hex.EncodeToString(addressBytes))
+		address, err := helpers.ConvertBech32(v.OperatorAddress, s.cfg.Chain.ValidatorPrefix, s.cfg.Chain.AccountPrefix)
 		if err != nil {
-			return nil, fmt.Errorf("types.AccAddressFromHex: %s", err.Error())
+			return nil, fmt.Errorf("helpers.ConvertBech32: %s", err.Error())
 		}
-		totalVotes, err := s.dao.GetTotalVotesByAddress(address.String())
+		totalVotes, err := s.dao.GetTotalVotesByAddress(address)
 		if err != nil {
 			return nil, fmt.Errorf("dao.GetTotalVotesByAddress: %s", err.Error())
 		}
@@ -139,38 +146,40 @@ func (s *ServiceFacade) makeValidators() (validators []smodels.Validator, err er
 			return nil, fmt.Errorf("dao.GetDelegatorsTotal: %s", err.Error())
 		}
 
-		power24Change, err := s.dao.GetVotingPower(filters.VotingPower{
-			TimeRange: filters.TimeRange{
-				From: dmodels.NewTime(time.Now().Add(-time.Hour * 24)),
-				To:   dmodels.NewTime(time.Now()),
-			},
-			Validators: []string{v.OperatorAddress},
-		})
+		power24Change := s.cfg.Chain.Power(int64(powers[consAddress]) - int64(powers24[consAddress])).
+			Div(s.cfg.Chain.PrecisionDiv())
 
-		selfStake, err := s.node.GetDelegatorValidatorStake(address.String(), v.OperatorAddress)
+		selfStake, err := s.node.GetDelegatorValidatorStake(address, v.OperatorAddress)
 		if err != nil {
 			return nil, fmt.Errorf("node.GetDelegatorValidatorStake: %s", err.Error())
 		}
 
-		power := v.DelegatorShares.Div(node.PrecisionDiv)
+		power := v.DelegatorShares.Div(s.cfg.Chain.PrecisionDiv())
 		percentPower := decimal.Zero
 		if !stakingPool.Pool.BondedTokens.IsZero() {
 			percentPower = power.Div(stakingPool.Pool.BondedTokens).Mul(decimal.NewFromInt(100)).Truncate(2)
 		}
 
+		var apr *decimal.Decimal
+		if aprErr == nil {
+			value := validatorAPR(stakingAPR.APR, v.Commission.CommissionRates.Rate)
+			apr = &value
+		}
+
 		validators = append(validators, smodels.Validator{
 			Title:           v.Description.Moniker,
 			Power:           power,
 			PercentPower:    percentPower,
 			SelfStake:       selfStake,
 			Fee:             v.Commission.CommissionRates.Rate,
+			APR:             apr,
 			BlocksProposed:  blockProposed,
 			Delegators:      delegatorsTotal,
 			Power24Change:   power24Change,
 			GovernanceVotes: totalVotes,
 			Website:         v.Description.Website,
 			OperatorAddress: v.OperatorAddress,
-			AccAddress:      address.String(),
+			AccAddress:      address,
 			ConsAddress:     consAddress,
 		})
 	}
@@ -178,6 +187,50 @@ func (s *ServiceFacade) makeValidators() (validators []smodels.Validator, err er
 	return validators, nil
 }
 
+// getValidatorPowers returns the tendermint voting powers by the hex consensus addresses.
+func (s *ServiceFacade) getValidatorPowers(filter filters.ValidatorPowers) (map[string]uint64, error) {
+	items, err := s.dao.GetValidatorPowers(filter)
+	if err != nil {
+		return nil, fmt.Errorf("dao.GetValidatorPowers: %s", err.Error())
+	}
+	powers := make(map[string]uint64)
+	for _, item := range items {
+		powers[item.Validator] = item.Value
+	}
+	return powers, nil
+}
+
+// GetValidatorPowers returns the validator set with the tendermint voting powers at the height, the latest set when the height is empty.
+func (s *ServiceFacade) GetValidatorPowers(filter filters.ValidatorPowers) (items []smodels.ValidatorPower, err error) {
+	powers, err := s.dao.GetValidatorPowers(filter)
+	if err != nil {
+		return nil, fmt.Errorf("dao.GetValidatorPowers: %s", err.Error())
+	}
+	validators, err := s.GetValidatorMap()
+	if err != nil {
+		return nil, fmt.Errorf("GetValidatorMap: %s", err.Error())
+	}
+	mp := make(map[string]node.Validator)
+	for _, validator := range validators {
+		address, err := helpers.GetHexAddressFromBase64PK(validator.ConsensusPubkey.Key)
+		if err != nil {
+			return nil, fmt.Errorf("helpers.GetHexAddressFromBase64PK: %s", err.Error())
+		}
+		mp[address] = validator
+	}
+	items = make([]smodels.ValidatorPower, len(powers))
+	for i, power := range powers {
+		validator := mp[power.Validator]
+		items[i] = smodels.ValidatorPower{
+			ConsAddress:     power.Validator,
+			OperatorAddress: validator.OperatorAddress,
+			Title:           validator.Description.Moniker,
+			Power:           power.Value,
+		}
+	}
+	return items, nil
+}
+
 func (s *ServiceFacade) GetTopProposedBlocksValidators() (items []dmodels.ValidatorValue, err error) {
 	data, found := s.dao.CacheGet(topProposedBlocksValidatorsKey)
 	if found {
@@ -227,7 +280,11 @@ func (s *ServiceFacade) GetMostJailedValidators() (items []dmodels.ValidatorValu
 	}
 	mp := make(map[string]string)
 	for _, validator := range validators {
-		mp[validator.OperatorAddress] = validator.Description.Moniker
+		address, err := helpers.GetHexAddressFromBase64PK(validator.ConsensusPubkey.Key)
+		if err != nil {
+			return nil, fmt.Errorf("helpers.GetHexAddressFromBase64PK: %s", err.Error())
+		}
+		mp[address] = validator.Description.Moniker
 	}
 	for i, item := range items {
 		title, found := mp[item.Validator]
@@ -326,17 +383,28 @@ func (s *ServiceFacade) GetValidatorBalance(valAddress string) (balance smodels.
 	}
 	balance.SelfDelegated = validator.SelfStake
 	balance.OtherDelegated = validator.Power.Sub(validator.SelfStake)
-	addressBytes, err := types.GetFromBech32(valAddress, types.Bech32PrefixValAddr)
+	address, err := helpers.ConvertBech32(valAddress, s.cfg.Chain.ValidatorPrefix, s.cfg.Chain.AccountPrefix)
 	if err != nil {
-		return balance, fmt.Errorf("types.GetFromBech32: %s", err.Error())
+		return balance, fmt.Errorf("helpers.ConvertBech32: %s", err.Error())
 	}
-	address, err := types.AccAddressFromHex(hex.EncodeToString(addressBytes))
-	if err != nil {
-		return balance, fmt.Errorf("types.AccAddressFromHex: %s", err.Error())
-	}
-	balance.Available, err = s.node.GetBalance(address.String())
+	balance.Available, err = s.node.GetBalance(address)
 	if err != nil {
 		return balance, fmt.Errorf("node.GetBalance: %s", err.Error())
 	}
 	return balance, nil
 }
+
+func (s *ServiceFacade) GetValidatorHistory(filter filters.ValidatorEvents) (resp smodels.PaginatableResponse, err error) {
+	items, err := s.dao.GetValidatorEvents(filter)
+	if err != nil {
+		return resp, fmt.Errorf("dao.GetValidatorEvents: %s", err.Error())
+	}
+	total, err := s.dao.GetValidatorEventsTotal(filter)
+	if err != nil {
+		return resp, fmt.Errorf("dao.GetValidatorEventsTotal: %s", err.Error())
+	}
+	return smodels.PaginatableResponse{
+		Items: items,
+		Total: total,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package smodels
+
+import (
+	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	AccountTransactionTransferIn   = "transfer_in"
+	AccountTransactionTransferOut  = "transfer_out"
+	AccountTransactionDelegation   = "delegation"
+	AccountTransactionUndelegation = "undelegation"
+	AccountTransactionReward       = "reward"
+	AccountTransactionVote         = "vote"
+	AccountTransactionDeposit      = "deposit"
+)
+
+type Account struct {
+	Address          string              `json:"address"`
+	Balance          decimal.Decimal     `json:"balance"`
+	Stake            decimal.Decimal     `json:"stake"`
+	Unbonding        decimal.Decimal     `json:"unbonding"`
+	FirstSeen        dmodels.Time        `json:"first_seen"`
+	Delegations      []AccountDelegation `json:"delegations"`
+	RewardsWithdrawn decimal.Decimal     `json:"rewards_withdrawn"`
+	GovernanceVotes  uint64              `json:"governance_votes"`
+}
+
+type AccountDelegation struct {
+	Validator string          `db:"validator" json:"validator"`
+	Title     string          `db:"-" json:"title"`
+	Amount    decimal.Decimal `db:"amount" json:"amount"`
+}
+
+// AccountTransaction is an operation of the account, the counterparty is
+// the other address of a transfer, the validator or the proposal id.
+type AccountTransaction struct {
+	TxHash       string          `db:"tx_hash" json:"tx_hash"`
+	Type         string          `db:"type" json:"type"`
+	Amount       decimal.Decimal `db:"amount" json:"amount"`
+	Currency     string          `db:"currency" json:"currency"`
+	Counterparty string          `db:"counterparty" json:"counterparty"`
+	CreatedAt    dmodels.Time    `db:"created_at" json:"created_at"`
+}
@@ -0,0 +1,41 @@
+package smodels
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+type AccountBalance struct {
+	Address   string          `json:"address"`
+	Balance   decimal.Decimal `json:"balance"`
+	Stake     decimal.Decimal `json:"stake"`
+	Unbonding decimal.Decimal `json:"unbonding"`
+	Total     decimal.Decimal `json:"total"`
+}
+
+type AccountsDistribution struct {
+	Ranges        []DistributionRange `json:"ranges"`
+	Accounts      uint64              `json:"accounts"`
+	Amount        decimal.Decimal     `json:"amount"`
+	Gini          decimal.Decimal     `json:"gini"`
+	Concentration []TopConcentration  `json:"concentration"`
+}
+
+// DistributionRange is a range of the holdings, the last range has no upper bound.
+type DistributionRange struct {
+	From     decimal.Decimal  `json:"from"`
+	To       *decimal.Decimal `json:"to"`
+	Accounts uint64           `json:"accounts"`
+	Amount   decimal.Decimal  `json:"amount"`
+}
+
+// TopConcentration is the percent of the holdings kept by the largest accounts.
+type TopConcentration struct {
+	Accounts uint64          `json:"accounts"`
+	Share    decimal.Decimal `json:"share"`
+}
+
+type DistributionRangeAgg struct {
+	From  decimal.Decimal  `json:"from"`
+	To    *decimal.Decimal `json:"to"`
+	Items []AggItem        `json:"items"`
+}
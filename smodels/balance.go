@@ -1,9 +1,19 @@
 package smodels
 
-import "github.com/shopspring/decimal"
+import (
+	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/shopspring/decimal"
+)
 
 type Balance struct {
 	SelfDelegated  decimal.Decimal `json:"self_delegated"`
 	OtherDelegated decimal.Decimal `json:"other_delegated"`
 	Available      decimal.Decimal `json:"available"`
 }
+
+type BalanceAggItem struct {
+	Time      dmodels.Time    `db:"time" json:"time"`
+	Balance   decimal.Decimal `db:"balance" json:"balance"`
+	Stake     decimal.Decimal `db:"stake" json:"stake"`
+	Unbonding decimal.Decimal `db:"unbonding" json:"unbonding"`
+}
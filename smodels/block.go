@@ -0,0 +1,15 @@
+package smodels
+
+import "github.com/kwanifi/numiscan-api/dmodels"
+
+type Block struct {
+	Height    uint64       `json:"height"`
+	Hash      string       `json:"hash"`
+	Proposer  string       `json:"proposer"`
+	CreatedAt dmodels.Time `json:"created_at"`
+}
+
+type BlockDetails struct {
+	Block
+	Transactions []Transaction `json:"transactions"`
+}
@@ -0,0 +1,23 @@
+package smodels
+
+import "github.com/shopspring/decimal"
+
+type (
+	// Decentralization describes how the stake is spread over the active set. Nakamoto coefficients are the numbers
+	// of the largest validators holding more than 1/3 and 2/3 of the stake, the shares are in percents.
+	Decentralization struct {
+		Validators      uint64          `json:"validators"`
+		Nakamoto33      uint64          `json:"nakamoto_33"`
+		Nakamoto66      uint64          `json:"nakamoto_66"`
+		Gini            decimal.Decimal `json:"gini"`
+		HHI             decimal.Decimal `json:"hhi"`
+		BottomHalfShare decimal.Decimal `json:"bottom_half_share"`
+	}
+	DecentralizationAgg struct {
+		Nakamoto33      []AggItem `json:"nakamoto_33"`
+		Nakamoto66      []AggItem `json:"nakamoto_66"`
+		Gini            []AggItem `json:"gini"`
+		HHI             []AggItem `json:"hhi"`
+		BottomHalfShare []AggItem `json:"bottom_half_share"`
+	}
+)
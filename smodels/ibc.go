@@ -0,0 +1,13 @@
+package smodels
+
+import (
+	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/shopspring/decimal"
+)
+
+type IBCChannelAggItem struct {
+	Channel string          `db:"channel" json:"channel"`
+	Denom   string          `db:"denom" json:"denom"`
+	Time    dmodels.Time    `db:"time" json:"time"`
+	Value   decimal.Decimal `db:"value" json:"value"`
+}
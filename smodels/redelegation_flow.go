@@ -0,0 +1,13 @@
+package smodels
+
+import "github.com/shopspring/decimal"
+
+// RedelegationFlow is the stake moved from the source validator to the destination one.
+type RedelegationFlow struct {
+	Source           string          `db:"source" json:"source"`
+	SourceTitle      string          `db:"-" json:"source_title"`
+	Destination      string          `db:"destination" json:"destination"`
+	DestinationTitle string          `db:"-" json:"destination_title"`
+	Amount           decimal.Decimal `db:"amount" json:"amount"`
+	Count            uint64          `db:"count" json:"count"`
+}
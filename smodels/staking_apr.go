@@ -0,0 +1,13 @@
+package smodels
+
+import "github.com/shopspring/decimal"
+
+// StakingAPR is the yearly staking yield in percents. APR is estimated from the inflation before the commission
+// of validators, realized APR is the yield of the rewards withdrawn by delegators over the last 30 days.
+type StakingAPR struct {
+	APR          decimal.Decimal `json:"apr"`
+	RealizedAPR  decimal.Decimal `json:"realized_apr"`
+	Inflation    decimal.Decimal `json:"inflation"`
+	CommunityTax decimal.Decimal `json:"community_tax"`
+	BondedRatio  decimal.Decimal `json:"bonded_ratio"`
+}
@@ -0,0 +1,31 @@
+package smodels
+
+import "github.com/shopspring/decimal"
+
+type (
+	// StakingCalculation is the projection of the rewards of a delegation, APR is the yearly yield
+	// in percents after the commission of the validator. The uptime fields project the same with
+	// the APR reduced by the uptime of the validator.
+	StakingCalculation struct {
+		Amount        decimal.Decimal        `json:"amount"`
+		Days          uint64                 `json:"days"`
+		Compound      bool                   `json:"compound"`
+		APR           decimal.Decimal        `json:"apr"`
+		Rewards       decimal.Decimal        `json:"rewards"`
+		Total         decimal.Decimal        `json:"total"`
+		UptimeAPR     decimal.Decimal        `json:"uptime_apr"`
+		UptimeRewards decimal.Decimal        `json:"uptime_rewards"`
+		UptimeTotal   decimal.Decimal        `json:"uptime_total"`
+		Assumptions   StakingCalcAssumptions `json:"assumptions"`
+	}
+	StakingCalcAssumptions struct {
+		Inflation    decimal.Decimal `json:"inflation"`
+		CommunityTax decimal.Decimal `json:"community_tax"`
+		BondedRatio  decimal.Decimal `json:"bonded_ratio"`
+		NetworkAPR   decimal.Decimal `json:"network_apr"`
+		Commission   decimal.Decimal `json:"commission"`
+		Uptime       decimal.Decimal `json:"uptime"`
+		UptimeWindow uint64          `json:"uptime_window"`
+		Bonded       bool            `json:"bonded"`
+	}
+)
@@ -0,0 +1,73 @@
+package smodels
+
+import (
+	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/shopspring/decimal"
+)
+
+type MessageTypeAggItem struct {
+	Type  string       `db:"type" json:"type"`
+	Time  dmodels.Time `db:"time" json:"time"`
+	Value uint64       `db:"value" json:"value"`
+}
+
+type Transaction struct {
+	Hash         string          `json:"hash"`
+	Status       string          `json:"status"`
+	Height       uint64          `json:"height"`
+	Messages     uint64          `json:"messages"`
+	MessageTypes []string        `json:"message_types"`
+	Fee          decimal.Decimal `json:"fee"`
+	GasUsed      uint64          `json:"gas_used"`
+	GasWanted    uint64          `json:"gas_wanted"`
+	Memo         string          `json:"memo"`
+	FeePayer     string          `json:"fee_payer"`
+	FeeGranter   string          `json:"fee_granter"`
+	Signers      []string        `json:"signers"`
+	RawLog       string          `json:"raw_log"`
+	CreatedAt    dmodels.Time    `json:"created_at"`
+}
+
+type TransactionDetails struct {
+	Transaction
+	Transfers        []Transfer                `json:"transfers"`
+	Delegations      []Delegation              `json:"delegations"`
+	DelegatorRewards []DelegatorReward         `json:"delegator_rewards"`
+	ValidatorRewards []ValidatorReward         `json:"validator_rewards"`
+	Votes            []dmodels.ProposalVote    `json:"votes"`
+	Deposits         []dmodels.ProposalDeposit `json:"deposits"`
+}
+
+type Transfer struct {
+	TxHash    string          `json:"tx_hash"`
+	From      string          `json:"from"`
+	To        string          `json:"to"`
+	Amount    decimal.Decimal `json:"amount"`
+	Currency  string          `json:"currency"`
+	CreatedAt dmodels.Time    `json:"created_at"`
+}
+
+type Delegation struct {
+	TxHash    string          `json:"tx_hash"`
+	Delegator string          `json:"delegator"`
+	Validator string          `json:"validator"`
+	Amount    decimal.Decimal `json:"amount"`
+	CreatedAt dmodels.Time    `json:"created_at"`
+}
+
+type DelegatorReward struct {
+	TxHash    string          `json:"tx_hash"`
+	Delegator string          `json:"delegator"`
+	Validator string          `json:"validator"`
+	Amount    decimal.Decimal `json:"amount"`
+	Currency  string          `json:"currency"`
+	CreatedAt dmodels.Time    `json:"created_at"`
+}
+
+type ValidatorReward struct {
+	TxHash    string          `json:"tx_hash"`
+	Address   string          `json:"address"`
+	Amount    decimal.Decimal `json:"amount"`
+	Currency  string          `json:"currency"`
+	CreatedAt dmodels.Time    `json:"created_at"`
+}
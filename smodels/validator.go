@@ -2,18 +2,27 @@ package smodels
 
 import "github.com/shopspring/decimal"
 
+// Validator is an item of the validators list, APR is null when the network APR is not available.
 type Validator struct {
-	Title           string          `json:"title"`
-	Website         string          `json:"website"`
-	OperatorAddress string          `json:"operator_address"`
-	AccAddress      string          `json:"acc_address"`
-	ConsAddress     string          `json:"cons_address"`
-	PercentPower    decimal.Decimal `json:"percent_power"`
-	Power           decimal.Decimal `json:"power"`
-	SelfStake       decimal.Decimal `json:"self_stake"`
-	Fee             decimal.Decimal `json:"fee"`
-	BlocksProposed  uint64          `json:"blocks_proposed"`
-	Delegators      uint64          `json:"delegators"`
-	Power24Change   decimal.Decimal `json:"power_24_change"`
-	GovernanceVotes uint64          `json:"governance_votes"`
+	Title           string           `json:"title"`
+	Website         string           `json:"website"`
+	OperatorAddress string           `json:"operator_address"`
+	AccAddress      string           `json:"acc_address"`
+	ConsAddress     string           `json:"cons_address"`
+	PercentPower    decimal.Decimal  `json:"percent_power"`
+	Power           decimal.Decimal  `json:"power"`
+	SelfStake       decimal.Decimal  `json:"self_stake"`
+	Fee             decimal.Decimal  `json:"fee"`
+	APR             *decimal.Decimal `json:"apr"`
+	BlocksProposed  uint64           `json:"blocks_proposed"`
+	Delegators      uint64           `json:"delegators"`
+	Power24Change   decimal.Decimal  `json:"power_24_change"`
+	GovernanceVotes uint64           `json:"governance_votes"`
+}
+
+type ValidatorPower struct {
+	ConsAddress     string `json:"cons_address"`
+	OperatorAddress string `json:"operator_address"`
+	Title           string `json:"title"`
+	Power           uint64 `json:"power"`
 }
@@ -0,0 +1,17 @@
+package smodels
+
+import (
+	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/shopspring/decimal"
+)
+
+// ValidatorRevenueAggItem is the revenue of the validator for the period in the staking currency.
+// The commission is the revenue of the validator, the proposer reward is already split into the commission
+// and the rewards of the delegators, withdrawn is the commission taken by the validator.
+type ValidatorRevenueAggItem struct {
+	Time           dmodels.Time    `db:"time" json:"time"`
+	Commission     decimal.Decimal `db:"commission" json:"commission"`
+	Rewards        decimal.Decimal `db:"rewards" json:"rewards"`
+	ProposerReward decimal.Decimal `db:"proposer_reward" json:"proposer_reward"`
+	Withdrawn      decimal.Decimal `db:"-" json:"withdrawn"`
+}
@@ -0,0 +1,32 @@
+package smodels
+
+import "github.com/shopspring/decimal"
+
+// ValidatorUptime is the uptime over the heights of the signed blocks window where the validator was in the active set,
+// Active is the number of these heights. The blocks bitmap covers the requested heights from FromHeight to ToHeight,
+// `1` is signed, `0` is missed and `-` is out of the active set.
+type ValidatorUptime struct {
+	Window              uint64          `json:"window"`
+	Active              uint64          `json:"active"`
+	Missed              uint64          `json:"missed"`
+	Uptime              decimal.Decimal `json:"uptime"`
+	LongestMissedStreak uint64          `json:"longest_missed_streak"`
+	CurrentMissedStreak uint64          `json:"current_missed_streak"`
+	FromHeight          uint64          `json:"from_height"`
+	ToHeight            uint64          `json:"to_height"`
+	Blocks              string          `json:"blocks"`
+}
+
+type ValidatorsUptime struct {
+	Window     uint64                `json:"window"`
+	ToHeight   uint64                `json:"to_height"`
+	Validators []ValidatorUptimeItem `json:"validators"`
+}
+
+type ValidatorUptimeItem struct {
+	OperatorAddress string          `json:"operator_address"`
+	Title           string          `json:"title"`
+	Missed          uint64          `json:"missed"`
+	Active          uint64          `json:"active"`
+	Uptime          decimal.Decimal `json:"uptime"`
+}
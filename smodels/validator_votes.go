@@ -0,0 +1,50 @@
+package smodels
+
+import (
+	"github.com/kwanifi/numiscan-api/dmodels"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	// LastVote is the final vote of the voter on the proposal.
+	LastVote struct {
+		ProposalID uint64       `db:"proposal_id"`
+		Voter      string       `db:"voter"`
+		Option     string       `db:"option"`
+		TxHash     string       `db:"tx_hash"`
+		VotedAt    dmodels.Time `db:"voted_at"`
+	}
+	ProposalCount struct {
+		ProposalID uint64 `db:"proposal_id"`
+		Value      uint64 `db:"value"`
+	}
+
+	ValidatorVotes struct {
+		Participation Participation   `json:"participation"`
+		Votes         []ValidatorVote `json:"votes"`
+	}
+	// ValidatorVote is the vote of the validator on the proposal, overrides are the delegators of the validator
+	// who voted themselves and so replaced the vote of the validator for their stake.
+	ValidatorVote struct {
+		ProposalID         uint64       `json:"proposal_id"`
+		Title              string       `json:"title"`
+		Status             string       `json:"status"`
+		VotingEndTime      dmodels.Time `json:"voting_end_time"`
+		Option             string       `json:"option"`
+		TxHash             string       `json:"tx_hash"`
+		VotedAt            dmodels.Time `json:"voted_at"`
+		DelegatorOverrides uint64       `json:"delegator_overrides"`
+		Overridden         bool         `json:"overridden"`
+	}
+	// Participation is the percent of the last proposals ended in voting the validator voted on.
+	Participation struct {
+		Proposals uint64          `json:"proposals"`
+		Voted     uint64          `json:"voted"`
+		Score     decimal.Decimal `json:"score"`
+	}
+	ValidatorParticipation struct {
+		OperatorAddress string `json:"operator_address"`
+		Title           string `json:"title"`
+		Participation
+	}
+)